@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+const adminUsage = `usage /admin <command>:
+  users [query]                 -- list accounts
+  disable <user> [reason]       -- disable an account and disconnect it
+  enable <user>                 -- re-enable an account
+  delete <user> [reason]        -- delete an account
+  role <user> <role>            -- set role: user, moderator, admin
+  reset <user>                  -- issue a password reset token
+  kick <user> [reason]          -- force-disconnect the user's session
+  delete_group <group> [reason] -- delete a group and its messages
+  purge_user <user> [reason]    -- delete all messages sent by a user
+  purge_group <group> [reason]  -- delete all messages of a group
+  audit [actor]                 -- show the audit log
+  retention                     -- list message retention policies
+  retention type <private|group|system> <days|off> [archive]
+  retention group <group> <days|off> [archive]
+                                -- purge messages older than days, archiving them first`
+
+// runAdminCommand handles "/admin ..." lines using AdminService
+func runAdminCommand(ctx context.Context, admin pb.AdminServiceClient, logger *log.Logger, line string) {
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 2 {
+		fmt.Println(adminUsage)
+		return
+	}
+	cmd := parts[1]
+	arg := func(i int) string {
+		if i < len(parts) {
+			return parts[i]
+		}
+		return ""
+	}
+	// reason là phần còn lại sau tham số thứ nhất
+	rest := strings.TrimSpace(strings.Join(parts[min(3, len(parts)):], " "))
+
+	logger.Printf("Admin command: %s %s", cmd, arg(2))
+	var (
+		res *pb.AdminResponse
+		err error
+	)
+	switch cmd {
+	case "users":
+		list, err := admin.ListUsers(ctx, &pb.AdminListUsersRequest{Query: strings.TrimSpace(arg(2) + " " + rest)})
+		if err != nil {
+			fmt.Println("admin err:", err)
+			return
+		}
+		fmt.Printf("Accounts (%d):\n", len(list.Users))
+		for _, u := range list.Users {
+			flags := u.Role
+			if u.IsBot {
+				flags += ", bot"
+			}
+			if u.Disabled {
+				flags += ", disabled"
+			}
+			if u.IsOnline {
+				flags += ", online"
+			}
+			fmt.Printf("  - %s (%s) [%s]\n", u.Username, u.DisplayName, flags)
+		}
+		return
+	case "disable":
+		res, err = admin.DisableUser(ctx, &pb.AdminUserRequest{Username: arg(2), Reason: rest})
+	case "enable":
+		res, err = admin.EnableUser(ctx, &pb.AdminUserRequest{Username: arg(2), Reason: rest})
+	case "delete":
+		res, err = admin.DeleteUser(ctx, &pb.AdminUserRequest{Username: arg(2), Reason: rest})
+	case "role":
+		res, err = admin.SetUserRole(ctx, &pb.SetUserRoleRequest{Username: arg(2), Role: rest})
+	case "reset":
+		reset, err := admin.IssuePasswordReset(ctx, &pb.AdminUserRequest{Username: arg(2), Reason: rest})
+		if err != nil {
+			fmt.Println("admin err:", err)
+			return
+		}
+		if !reset.Ok {
+			fmt.Println("admin failed:", reset.Message)
+			return
+		}
+		fmt.Printf("Reset token for %s (until %s): %s\n", arg(2),
+			time.Unix(reset.ExpiresAt, 0).Format("15:04:05"), reset.ResetToken)
+		return
+	case "kick":
+		res, err = admin.ForceDisconnect(ctx, &pb.ForceDisconnectRequest{Username: arg(2), Reason: rest})
+	case "delete_group":
+		res, err = admin.DeleteGroup(ctx, &pb.AdminGroupRequest{GroupName: arg(2), Reason: rest})
+	case "purge_user", "purge_group":
+		req := &pb.PurgeMessagesRequest{Reason: rest}
+		if cmd == "purge_user" {
+			req.FromUser = arg(2)
+		} else {
+			req.GroupName = arg(2)
+		}
+		purge, err := admin.PurgeMessages(ctx, req)
+		if err != nil {
+			fmt.Println("admin err:", err)
+			return
+		}
+		fmt.Printf("%s (%d deleted)\n", purge.Message, purge.Deleted)
+		return
+	case "retention":
+		if arg(2) == "" {
+			list, err := admin.ListRetentionPolicies(ctx, &pb.Empty{})
+			if err != nil {
+				fmt.Println("admin err:", err)
+				return
+			}
+			if len(list.Policies) == 0 {
+				fmt.Println("No retention policies, messages are kept forever")
+			}
+			for _, p := range list.Policies {
+				scope := "type " + p.MessageType
+				if p.GroupId != 0 {
+					scope = "group " + p.GroupName
+				}
+				archive := ""
+				if p.Archive {
+					archive = ", archived"
+				}
+				fmt.Printf("  - %s: %d days%s (by %s at %s)\n", scope, p.RetainDays, archive, p.UpdatedBy,
+					time.Unix(p.UpdatedAt, 0).Format("2006-01-02 15:04"))
+			}
+			if list.Partitioned {
+				fmt.Println("messages is partitioned by month")
+			}
+			return
+		}
+		// retention <type|group> <name> <days|off> [archive]
+		fields := strings.Fields(rest)
+		if (arg(2) != "type" && arg(2) != "group") || len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && fields[2] != "archive") {
+			fmt.Println(adminUsage)
+			return
+		}
+		req := &pb.SetRetentionPolicyRequest{Archive: len(fields) == 3}
+		if arg(2) == "type" {
+			req.MessageType = fields[0]
+		} else {
+			req.GroupName = fields[0]
+		}
+		if fields[1] != "off" {
+			days, err := strconv.Atoi(fields[1])
+			if err != nil || days <= 0 {
+				fmt.Println("days must be a positive number, or off")
+				return
+			}
+			req.RetainDays = int32(days)
+		}
+		res, err = admin.SetRetentionPolicy(ctx, req)
+	case "audit":
+		audit, err := admin.ListAuditLog(ctx, &pb.ListAuditLogRequest{Actor: arg(2), Limit: 20})
+		if err != nil {
+			fmt.Println("admin err:", err)
+			return
+		}
+		for _, e := range audit.Entries {
+			ts := time.Unix(e.CreatedAt, 0).Format("2006-01-02 15:04:05")
+			fmt.Printf("  [%s] %s %s %s %s\n", ts, e.Actor, e.Action, e.Target, e.Details)
+		}
+		return
+	default:
+		fmt.Println(adminUsage)
+		return
+	}
+
+	if err != nil {
+		logger.Printf("Admin command %s failed: %v", cmd, err)
+		fmt.Println("admin err:", err)
+	} else {
+		fmt.Println(res.Message)
+	}
+}
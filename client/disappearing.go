@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+// expired reports whether a disappearing message is past its expiry; the
+// server deletes it shortly after
+func expired(m *pb.ChatMessage, now time.Time) bool {
+	return m.ExpiresAt > 0 && now.Unix() >= m.ExpiresAt
+}
+
+// expiryMark renders when a disappearing message goes away, e.g. " (disappears in 59m)"
+func expiryMark(m *pb.ChatMessage, now time.Time) string {
+	switch {
+	case m.ExpireTtl == 0:
+		return ""
+	case m.ExpiresAt == 0:
+		return fmt.Sprintf(" (disappears %s after read)", time.Duration(m.ExpireTtl)*time.Second)
+	}
+	left := time.Unix(m.ExpiresAt, 0).Sub(now).Round(time.Second)
+	if left < 0 {
+		left = 0
+	}
+	return fmt.Sprintf(" (disappears in %s)", left)
+}
+
+// runDisappearCommand handles /disappear.
+// It returns false when line is not /disappear.
+func runDisappearCommand(ctx context.Context, client pb.ChatServiceClient, logger *log.Logger, line string) bool {
+	if line != "/disappear" && !strings.HasPrefix(line, "/disappear ") {
+		return false
+	}
+	parts := strings.Fields(line)
+	if len(parts) < 2 || len(parts) > 4 || (len(parts) == 4 && parts[3] != "read") {
+		fmt.Println("usage /disappear <@user|group> [off|<duration> [read]]")
+		return true
+	}
+
+	chatType, target := "group", parts[1]
+	if strings.HasPrefix(target, "@") {
+		chatType, target = "private", strings.TrimPrefix(target, "@")
+	}
+
+	if len(parts) == 2 {
+		res, err := client.GetDisappearing(ctx, &pb.DisappearingRequest{ChatType: chatType, Target: target})
+		if err != nil {
+			logger.Printf("Error loading disappearing setting of %s: %v", parts[1], err)
+			fmt.Println("disappear err:", err)
+			return true
+		}
+		fmt.Println(res.Message)
+		if res.TtlSeconds > 0 {
+			fmt.Printf("  set by %s at %s\n", res.SetBy, time.Unix(res.UpdatedAt, 0).Format("2006-01-02 15:04"))
+		}
+		return true
+	}
+
+	req := &pb.SetDisappearingRequest{ChatType: chatType, Target: target}
+	if parts[2] != "off" {
+		ttl, err := time.ParseDuration(parts[2])
+		if err != nil || ttl <= 0 {
+			fmt.Println("invalid duration, e.g. 30s, 1h or 168h")
+			return true
+		}
+		req.TtlSeconds = int64(ttl / time.Second)
+		if len(parts) == 4 {
+			req.Mode = "read"
+		}
+	}
+	res, err := client.SetDisappearing(ctx, req)
+	if err != nil {
+		logger.Printf("Error setting disappearing messages of %s: %v", parts[1], err)
+		fmt.Println("disappear err:", err)
+		return true
+	}
+	logger.Printf("Disappearing messages of %s: %s", parts[1], res.Message)
+	fmt.Println(res.Message)
+	return true
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+// uploadChunkSize must stay within the server limit of 256 KiB per chunk
+const uploadChunkSize = 64 << 10
+
+// formatAttachments renders the files of a message, e.g. "[file #3 report.pdf, 12.0 KiB]"
+func formatAttachments(attachments []*pb.Attachment) string {
+	parts := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		parts = append(parts, fmt.Sprintf("[file #%d %s, %s]", a.Id, a.Filename, formatSize(a.Size)))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// detectContentType guesses the type of a file from its extension, then from its first bytes
+func detectContentType(f *os.File) (string, error) {
+	if t := mime.TypeByExtension(filepath.Ext(f.Name())); t != "" {
+		return t, nil
+	}
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// uploadFile streams a local file to the server in chunks
+func uploadFile(ctx context.Context, client pb.ChatServiceClient, path string) (*pb.UploadFileResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := detectContentType(f)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := client.UploadFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &pb.UploadFileInfo{Filename: filepath.Base(path), ContentType: contentType, Size: stat.Size()}
+	if err := up.Send(&pb.UploadFileRequest{Payload: &pb.UploadFileRequest_Info{Info: info}}); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			// Server từ chối giữa chừng thì Send trả io.EOF, lý do nằm trong response
+			if err := up.Send(&pb.UploadFileRequest{Payload: &pb.UploadFileRequest_Chunk{Chunk: buf[:n]}}); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return up.CloseAndRecv()
+}
+
+// downloadFile saves an attachment to dest, or to its own name in the current
+// directory when dest is empty
+func downloadFile(ctx context.Context, client pb.ChatServiceClient, id int64, dest string) (string, int64, error) {
+	down, err := client.DownloadFile(ctx, &pb.DownloadFileRequest{AttachmentId: id})
+	if err != nil {
+		return "", 0, err
+	}
+
+	var out *os.File
+	var written int64
+	for {
+		chunk, err := down.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if out != nil {
+				out.Close()
+				os.Remove(out.Name())
+			}
+			return "", 0, err
+		}
+		if out == nil {
+			name := dest
+			if name == "" {
+				name = filepath.Base(chunk.GetInfo().GetFilename())
+			}
+			if out, err = os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644); err != nil {
+				return "", 0, err
+			}
+		}
+		n, err := out.Write(chunk.Data)
+		written += int64(n)
+		if err != nil {
+			out.Close()
+			os.Remove(out.Name())
+			return "", 0, err
+		}
+	}
+	if out == nil {
+		return "", 0, fmt.Errorf("empty download")
+	}
+	return out.Name(), written, out.Close()
+}
+
+// runFileCommand handles /send_file and /download.
+// It returns false when line is not one of them.
+func runFileCommand(ctx context.Context, client pb.ChatServiceClient, stream pb.ChatService_ChatStreamClient, username string, logger *log.Logger, line string) bool {
+	switch {
+	case strings.HasPrefix(line, "/send_file "):
+		parts := strings.SplitN(line, " ", 4)
+		if len(parts) < 3 {
+			fmt.Println("usage /send_file <@user|group> <path> [caption]")
+			return true
+		}
+		res, err := uploadFile(ctx, client, parts[2])
+		if err != nil {
+			logger.Printf("Error uploading %s: %v", parts[2], err)
+			fmt.Println("upload err:", err)
+			return true
+		}
+		if !res.Ok {
+			fmt.Println(res.Message)
+			return true
+		}
+		logger.Printf("Uploaded %s as attachment %d", parts[2], res.Attachment.Id)
+
+		msg := &pb.ChatMessage{
+			From:        username,
+			To:          parts[1],
+			Type:        "group",
+			Timestamp:   time.Now().Unix(),
+			Attachments: []*pb.Attachment{{Id: res.Attachment.Id}},
+		}
+		if strings.HasPrefix(parts[1], "@") {
+			msg.To, msg.Type = strings.TrimPrefix(parts[1], "@"), "private"
+		}
+		if len(parts) == 4 {
+			msg.Text = parts[3]
+		}
+		if err := stream.Send(msg); err != nil {
+			logger.Printf("Error sending file to %s: %v", parts[1], err)
+			fmt.Println("send error:", err)
+			return true
+		}
+		fmt.Printf("Sent %s to %s\n", formatAttachments([]*pb.Attachment{res.Attachment}), parts[1])
+	case strings.HasPrefix(line, "/download "):
+		parts := strings.Fields(line)
+		var id int64
+		if len(parts) == 2 || len(parts) == 3 {
+			id, _ = strconv.ParseInt(strings.TrimPrefix(parts[1], "#"), 10, 64)
+		}
+		if id <= 0 {
+			fmt.Println("usage /download <file_id> [path]")
+			return true
+		}
+		dest := ""
+		if len(parts) == 3 {
+			dest = parts[2]
+		}
+		name, n, err := downloadFile(ctx, client, id, dest)
+		if err != nil {
+			logger.Printf("Error downloading attachment %d: %v", id, err)
+			fmt.Println("download err:", err)
+			return true
+		}
+		logger.Printf("Downloaded attachment %d to %s", id, name)
+		fmt.Printf("Saved %s (%s)\n", name, formatSize(n))
+	default:
+		return false
+	}
+	return true
+}
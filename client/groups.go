@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+// groupCommands lists the commands handled by runGroupCommand with their usage
+var groupCommands = map[string]string{
+	"/group_visibility": "/group_visibility <group> <public|private|invite_only>",
+	"/group_edit":       "/group_edit <group> <name|display_name|topic|description|kind|post|invite|mention> <value>",
+	"/invite":           "/invite <group> <user>",
+	"/invites":          "/invites",
+	"/accept":           "/accept <invitation_id>",
+	"/decline":          "/decline <invitation_id>",
+	"/join_requests":    "/join_requests <group>",
+	"/approve":          "/approve <request_id>",
+	"/reject":           "/reject <request_id>",
+	"/history":          "/history <group|@user> [limit]",
+	"/groups":           "/groups [query] [+offset]",
+	"/invite_link":      "/invite_link <group> [expiry e.g. 24h] [max_uses]",
+	"/invite_links":     "/invite_links <group>",
+	"/invite_revoke":    "/invite_revoke <code>",
+	"/redeem":           "/redeem <code>",
+	"/leave":            "/leave <group>",
+	"/kick":             "/kick <group> <user> [reason]",
+	"/ban":              "/ban <group> <user> [duration e.g. 24h|0] [reason]",
+	"/unban":            "/unban <group> <user>",
+	"/bans":             "/bans <group>",
+	"/workspaces":       "/workspaces",
+	"/workspace_create": "/workspace_create <slug> [name]",
+	"/workspace_add":    "/workspace_add <workspace> <user>",
+	"/workspace_remove": "/workspace_remove <workspace> <user>",
+}
+
+// runGroupCommand handles group membership and history commands.
+// It returns false when line is not one of them.
+func runGroupCommand(ctx context.Context, client pb.ChatServiceClient, logger *log.Logger, line string) bool {
+	parts := strings.Fields(line)
+	usage, ok := groupCommands[parts[0]]
+	if !ok {
+		return false
+	}
+	// id parses a numeric argument
+	id := func(i int) (int64, bool) {
+		if i >= len(parts) {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		return n, err == nil
+	}
+
+	logger.Printf("Group command: %s", line)
+	var (
+		res *pb.GroupActionResponse
+		err error
+	)
+	switch parts[0] {
+	case "/group_visibility":
+		if len(parts) != 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.SetGroupVisibility(ctx, &pb.SetGroupVisibilityRequest{GroupName: parts[1], Visibility: parts[2]})
+	case "/group_edit":
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) < 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		value := ""
+		if len(fields) == 4 {
+			value = fields[3]
+		}
+		req := &pb.UpdateGroupRequest{GroupName: fields[1]}
+		switch fields[2] {
+		case "name":
+			req.Name = &value
+		case "display_name":
+			req.DisplayName = &value
+		case "topic":
+			req.Topic = &value
+		case "description":
+			req.Description = &value
+		case "post":
+			req.PostPolicy = &value
+		case "invite":
+			req.InvitePolicy = &value
+		case "mention":
+			req.MentionPolicy = &value
+		case "kind":
+			req.Kind = &value
+		default:
+			fmt.Println("usage", usage)
+			return true
+		}
+		upd, err := client.UpdateGroup(ctx, req)
+		if err != nil {
+			fmt.Println("group edit err:", err)
+			return true
+		}
+		fmt.Println(upd.Message)
+		return true
+	case "/invite":
+		if len(parts) != 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.InviteToGroup(ctx, &pb.GroupMemberRequest{GroupName: parts[1], Username: parts[2]})
+	case "/invites":
+		list, err := client.ListInvitations(ctx, &pb.Empty{})
+		if err != nil {
+			fmt.Println("invites err:", err)
+			return true
+		}
+		if len(list.Invitations) == 0 {
+			fmt.Println("No pending invitations.")
+			return true
+		}
+		fmt.Printf("Pending invitations (%d):\n", len(list.Invitations))
+		for _, inv := range list.Invitations {
+			fmt.Printf("  - #%d %s (from %s)\n", inv.Id, inv.GroupName, inv.InvitedBy)
+		}
+		return true
+	case "/accept", "/decline":
+		n, ok := id(1)
+		if !ok {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.RespondInvitation(ctx, &pb.RespondInvitationRequest{InvitationId: n, Accept: parts[0] == "/accept"})
+	case "/join_requests":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		list, err := client.ListJoinRequests(ctx, &pb.GroupNameRequest{GroupName: parts[1]})
+		if err != nil {
+			fmt.Println("join requests err:", err)
+			return true
+		}
+		if !list.Ok {
+			fmt.Println(list.Message)
+			return true
+		}
+		if len(list.Requests) == 0 {
+			fmt.Println("No pending join requests.")
+			return true
+		}
+		fmt.Printf("Join requests for %s (%d):\n", parts[1], len(list.Requests))
+		for _, jr := range list.Requests {
+			fmt.Printf("  - #%d %s (since %s)\n", jr.Id, jr.Username, time.Unix(jr.CreatedAt, 0).Format("2006-01-02 15:04"))
+		}
+		return true
+	case "/approve", "/reject":
+		n, ok := id(1)
+		if !ok {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.ReviewJoinRequest(ctx, &pb.ReviewJoinRequestRequest{RequestId: n, Approve: parts[0] == "/approve"})
+	case "/invite_link":
+		if len(parts) < 2 || len(parts) > 4 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.CreateInviteRequest{GroupName: parts[1]}
+		if len(parts) > 2 {
+			ttl, err := time.ParseDuration(parts[2])
+			if err != nil {
+				fmt.Println("invalid expiry:", parts[2])
+				return true
+			}
+			req.ExpiresInSeconds = int64(ttl.Seconds())
+		}
+		if n, ok := id(3); ok {
+			req.MaxUses = int32(n)
+		}
+		inv, err := client.CreateInvite(ctx, req)
+		if err != nil {
+			fmt.Println("invite err:", err)
+			return true
+		}
+		if !inv.Ok {
+			fmt.Println(inv.Message)
+			return true
+		}
+		fmt.Printf("Invite code for %s: %s (share it, others join with /redeem %s)\n", parts[1], inv.Invite.Code, inv.Invite.Code)
+		return true
+	case "/invite_links":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		list, err := client.ListInvites(ctx, &pb.GroupNameRequest{GroupName: parts[1]})
+		if err != nil {
+			fmt.Println("invites err:", err)
+			return true
+		}
+		if !list.Ok {
+			fmt.Println(list.Message)
+			return true
+		}
+		fmt.Printf("Invite codes for %s (%d):\n", parts[1], len(list.Invites))
+		for _, inv := range list.Invites {
+			expires := "never"
+			if inv.ExpiresAt > 0 {
+				expires = time.Unix(inv.ExpiresAt, 0).Format("2006-01-02 15:04")
+			}
+			limit := "unlimited"
+			if inv.MaxUses > 0 {
+				limit = strconv.Itoa(int(inv.MaxUses))
+			}
+			note := ""
+			if inv.Revoked {
+				note = " [revoked]"
+			}
+			fmt.Printf("  - %s by %s, expires %s, used %d/%s%s\n", inv.Code, inv.CreatedBy, expires, inv.Uses, limit, note)
+			if len(inv.JoinedUsers) > 0 {
+				fmt.Printf("      joined: %s\n", strings.Join(inv.JoinedUsers, ", "))
+			}
+		}
+		return true
+	case "/invite_revoke":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.RevokeInvite(ctx, &pb.RevokeInviteRequest{Code: parts[1]})
+	case "/redeem":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.RedeemInvite(ctx, &pb.RedeemInviteRequest{Code: parts[1]})
+	case "/leave":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.LeaveGroup(ctx, &pb.GroupNameRequest{GroupName: parts[1]})
+	case "/kick":
+		if len(parts) < 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.RemoveMember(ctx, &pb.RemoveMemberRequest{
+			GroupName: parts[1],
+			Username:  parts[2],
+			Reason:    strings.Join(parts[3:], " "),
+		})
+	case "/ban":
+		if len(parts) < 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.BanMemberRequest{GroupName: parts[1], Username: parts[2]}
+		reason := parts[3:]
+		if len(reason) > 0 {
+			// tham số thứ 3 là duration nếu parse được, không thì là reason
+			if d, err := time.ParseDuration(reason[0]); err == nil {
+				req.DurationSeconds = int64(d.Seconds())
+				reason = reason[1:]
+			}
+		}
+		req.Reason = strings.Join(reason, " ")
+		res, err = client.BanMember(ctx, req)
+	case "/unban":
+		if len(parts) != 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.UnbanMember(ctx, &pb.GroupMemberRequest{GroupName: parts[1], Username: parts[2]})
+	case "/bans":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		list, err := client.ListBans(ctx, &pb.GroupNameRequest{GroupName: parts[1]})
+		if err != nil {
+			fmt.Println("bans err:", err)
+			return true
+		}
+		if !list.Ok {
+			fmt.Println(list.Message)
+			return true
+		}
+		if len(list.Bans) == 0 {
+			fmt.Println("No active bans.")
+			return true
+		}
+		fmt.Printf("Bans in %s (%d):\n", parts[1], len(list.Bans))
+		for _, b := range list.Bans {
+			until := "permanent"
+			if b.ExpiresAt > 0 {
+				until = "until " + time.Unix(b.ExpiresAt, 0).Format("2006-01-02 15:04")
+			}
+			fmt.Printf("  - %s by %s, %s %s\n", b.Username, b.BannedBy, until, b.Reason)
+		}
+		return true
+	case "/workspace_add", "/workspace_remove":
+		if len(parts) != 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.WorkspaceMemberRequest{Workspace: parts[1], Username: parts[2]}
+		if parts[0] == "/workspace_add" {
+			res, err = client.AddWorkspaceMember(ctx, req)
+		} else {
+			res, err = client.RemoveWorkspaceMember(ctx, req)
+		}
+	case "/workspace_create":
+		if len(parts) < 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		created, err := client.CreateWorkspace(ctx, &pb.CreateWorkspaceRequest{Slug: parts[1], Name: strings.Join(parts[2:], " ")})
+		if err != nil {
+			fmt.Println("workspace err:", err)
+			return true
+		}
+		fmt.Println(created.Message)
+		return true
+	case "/workspaces":
+		list, err := client.ListWorkspaces(ctx, &pb.Empty{})
+		if err != nil {
+			fmt.Println("workspaces err:", err)
+			return true
+		}
+		fmt.Printf("Your workspaces (%d):\n", len(list.Workspaces))
+		for _, w := range list.Workspaces {
+			fmt.Printf("  - %s \"%s\" (%d members, you: %s)\n", w.Slug, w.Name, w.MemberCount, w.MyRole)
+		}
+		return true
+	case "/groups":
+		// tham số cuối dạng +N là offset của trang tiếp theo
+		args := parts[1:]
+		var offset int64
+		if len(args) > 0 && strings.HasPrefix(args[len(args)-1], "+") {
+			n, err := strconv.ParseInt(args[len(args)-1][1:], 10, 32)
+			if err != nil {
+				fmt.Println("usage", usage)
+				return true
+			}
+			offset, args = n, args[:len(args)-1]
+		}
+		query := strings.Join(args, " ")
+		dir, err := client.SearchGroups(ctx, &pb.SearchGroupsRequest{Query: query, Offset: int32(offset)})
+		if err != nil {
+			fmt.Println("groups err:", err)
+			return true
+		}
+		if len(dir.Groups) == 0 {
+			fmt.Println("No public groups found.")
+			return true
+		}
+		fmt.Println("Public groups:")
+		for _, g := range dir.Groups {
+			kind := ""
+			if g.Kind == "channel" {
+				kind = " [channel]"
+			}
+			fmt.Printf("  - %s/%s \"%s\"%s (%d members, %d messages this week)\n", g.Workspace, g.Name, g.DisplayName, kind, g.MemberCount, g.RecentMessages)
+			if g.Topic != "" {
+				fmt.Printf("      topic: %s\n", g.Topic)
+			}
+		}
+		if dir.NextOffset > 0 {
+			fmt.Printf("More: /groups %s +%d\n", query, dir.NextOffset)
+		}
+		return true
+	case "/history":
+		if len(parts) < 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.GetHistoryRequest{Type: "group", Target: parts[1]}
+		if strings.HasPrefix(parts[1], "@") {
+			req.Type, req.Target = "private", strings.TrimPrefix(parts[1], "@")
+		}
+		if n, ok := id(2); ok {
+			req.Limit = int32(n)
+		}
+		hist, err := client.GetHistory(ctx, req)
+		if err != nil {
+			fmt.Println("history err:", err)
+			return true
+		}
+		if !hist.Ok {
+			fmt.Println(hist.Message)
+			return true
+		}
+		for _, m := range hist.Messages {
+			fmt.Println(formatStored(m))
+		}
+		return true
+	}
+
+	if err != nil {
+		logger.Printf("Group command %s failed: %v", parts[0], err)
+		fmt.Println("group err:", err)
+	} else {
+		fmt.Println(res.Message)
+	}
+	return true
+}
@@ -7,13 +7,17 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	pb "chat-grpc/proto"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -21,6 +25,7 @@ func main() {
 	fmt.Println("Welcome to gRPC Chat!")
 	fmt.Println("1) Register")
 	fmt.Println("2) Login")
+	fmt.Println("3) Reset password (token from admin)")
 	fmt.Print("Choose: ")
 	choice, _ := reader.ReadString('\n')
 	choice = strings.TrimSpace(choice)
@@ -33,6 +38,13 @@ func main() {
 	password, _ := reader.ReadString('\n')
 	password = strings.TrimSpace(password)
 
+	var resetToken string
+	if choice == "3" {
+		fmt.Print("Enter reset token: ")
+		resetToken, _ = reader.ReadString('\n')
+		resetToken = strings.TrimSpace(resetToken)
+	}
+
 	// Setup logging cho client với username trong tên file
 	logFileName := "client.log"
 	logFile, err := os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
@@ -53,27 +65,51 @@ func main() {
 	defer conn.Close()
 	log.Println("Connected to server at localhost:50051")
 	client := pb.NewChatServiceClient(conn)
+	admin := pb.NewAdminServiceClient(conn)
 
-	// Xử lý Register hoặc Login dựa trên choice
+	// Xử lý Register hoặc Reset password dựa trên choice, sau đó login
 	if choice == "1" {
 		log.Printf("Attempting to register user: %s", username)
 		res, err := client.Register(context.Background(), &pb.RegisterRequest{Username: username, Password: password})
-		if err != nil || !res.Ok {
+		if err != nil {
 			log.Fatalf("register failed: %v", err)
 		}
+		if !res.Ok {
+			log.Fatalf("register failed: %s", res.Message)
+		}
 		log.Printf("Registration successful: %s", res.Message)
-	} else {
-		log.Printf("Attempting to login user: %s", username)
-		res, err := client.Login(context.Background(), &pb.LoginRequest{Username: username, Password: password})
-		if err != nil || !res.Ok {
-			log.Fatalf("login failed: %v", err)
+	} else if choice == "3" {
+		log.Printf("Attempting to reset password for user: %s", username)
+		res, err := client.ResetPassword(context.Background(), &pb.ResetPasswordRequest{
+			Username:    username,
+			ResetToken:  resetToken,
+			NewPassword: password,
+		})
+		if err != nil {
+			log.Fatalf("reset password failed: %v", err)
+		}
+		if !res.Ok {
+			log.Fatalf("reset password failed: %s", res.Message)
 		}
-		log.Println("Login successful")
+		log.Printf("Password reset: %s", res.Message)
+	}
+
+	log.Printf("Attempting to login user: %s", username)
+	loginRes, err := client.Login(context.Background(), &pb.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		log.Fatalf("login failed: %v", err)
 	}
+	if !loginRes.Ok {
+		log.Fatalf("login failed: %s", loginRes.Message)
+	}
+	log.Printf("Login successful (session %d)", loginRes.SessionId)
+
+	// Mọi RPC sau khi login đều gửi kèm session token
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+loginRes.Token)
 
 	// Open ChatStream
 	log.Println("Opening chat stream...")
-	stream, err := client.ChatStream(context.Background())
+	stream, err := client.ChatStream(ctx)
 	if err != nil {
 		log.Fatalf("open stream: %v", err)
 	}
@@ -91,32 +127,153 @@ func main() {
 			in, err := stream.Recv()
 			if err != nil {
 				logger.Printf("recv error: %v", err)
+				if status.Code(err) == codes.Unauthenticated {
+					fmt.Println("Disconnected by server:", status.Convert(err).Message())
+					os.Exit(1)
+				}
 				return
 			}
+			// Conversation đã mute: chỉ ghi log, trừ khi được mention
+			if in.Muted && !in.Mentioned {
+				logger.Printf("Received %s message from %s in muted conversation %s: %s", in.Type, in.From, in.To, in.Text)
+				continue
+			}
 			// Display message
 			ts := time.Unix(in.Timestamp, 0).Format("15:04:05")
+			mark := ""
+			if in.Mentioned {
+				mark = " (@you)\a"
+			}
+			mark += expiryMark(in, time.Now())
 			switch in.Type {
 			case "private":
-				fmt.Printf("[%s][PM][%s -> you]: %s\n", ts, in.From, in.Text)
+				if in.ThreadRoot != 0 {
+					fmt.Printf("[%s][PM][%s -> you] #%d reply in thread #%d: %s\n", ts, in.From, in.Id, in.ThreadRoot, in.Text)
+					logger.Printf("Received PM reply from %s in thread %d: %s", in.From, in.ThreadRoot, in.Text)
+					break
+				}
+				fmt.Printf("[%s][PM][%s -> you] #%d: %s\n", ts, in.From, in.Id, in.Text)
 				logger.Printf("Received PM from %s: %s", in.From, in.Text)
 			case "group":
-				fmt.Printf("[%s][GROUP %s][%s]: %s\n", ts, in.To, in.From, in.Text)
+				if in.ThreadRoot != 0 {
+					fmt.Printf("[%s][GROUP %s][%s] #%d reply in thread #%d%s: %s\n", ts, in.To, in.From, in.Id, in.ThreadRoot, mark, in.Text)
+					logger.Printf("Received reply in %s thread %d from %s: %s", in.To, in.ThreadRoot, in.From, in.Text)
+					break
+				}
+				fmt.Printf("[%s][GROUP %s][%s] #%d%s: %s\n", ts, in.To, in.From, in.Id, mark, in.Text)
 				logger.Printf("Received group message in %s from %s: %s", in.To, in.From, in.Text)
+			case "error":
+				fmt.Printf("[%s][ERROR %s]: %s\n", ts, in.To, in.Text)
+				logger.Printf("Server error for %s: %s", in.To, in.Text)
+			case "system":
+				fmt.Printf("[%s][GROUP %s] * %s\n", ts, in.To, in.Text)
+				logger.Printf("System message in %s: %s", in.To, in.Text)
+			case "edit":
+				fmt.Printf("[%s][%s] %s edited #%d: %s\n", ts, in.To, in.From, in.Id, in.Text)
+				logger.Printf("Message %d edited by %s", in.Id, in.From)
+			case "delete":
+				if in.From == "system" {
+					fmt.Printf("[%s][%s] #%d disappeared\n", ts, in.To, in.Id)
+					logger.Printf("Message %d expired", in.Id)
+					break
+				}
+				fmt.Printf("[%s][%s] %s deleted #%d\n", ts, in.To, in.From, in.Id)
+				logger.Printf("Message %d deleted by %s", in.Id, in.From)
+			case "typing":
+				// Chỉ hiện start; stop / hết hạn chỉ ghi log
+				if in.Text == "start" {
+					where := "a private chat"
+					if in.ChatType == "group" {
+						where = in.To
+					}
+					fmt.Printf("[%s] %s is typing in %s...\n", ts, in.From, where)
+				}
+				logger.Printf("Typing %s: %s in %s", in.Text, in.From, in.To)
+			case "read":
+				fmt.Printf("[%s][%s] %s read up to #%d\n", ts, in.To, in.From, in.Id)
+				logger.Printf("Read receipt from %s in %s: %d", in.From, in.To, in.Id)
+			case "expire":
+				// Tin tự hủy kiểu "read" bắt đầu đếm
+				fmt.Printf("[%s][%s] #%d read by %s%s\n", ts, in.To, in.Id, in.From, expiryMark(in, time.Now()))
+				logger.Printf("Message %d expires at %d", in.Id, in.ExpiresAt)
+			case "pin", "unpin":
+				fmt.Printf("[%s][%s] %s %sned #%d: %s\n", ts, in.To, in.From, in.Type, in.Id, in.Text)
+				logger.Printf("Message %d %sned by %s", in.Id, in.Type, in.From)
+			case "react", "unreact":
+				fmt.Printf("[%s][%s] %s %sed %s on #%d: %s\n", ts, in.To, in.From, in.Type, in.Text, in.Id, formatReactions(in.Reactions))
+				logger.Printf("Reaction %s %s by %s on message %d", in.Type, in.Text, in.From, in.Id)
+			case "notice":
+				fmt.Printf("[%s][NOTICE %s]: %s\n", ts, in.To, in.Text)
+				logger.Printf("Notice for %s: %s", in.To, in.Text)
 			default:
 				fmt.Printf("[%s][%s]: %s\n", ts, in.From, in.Text)
 				logger.Printf("Received message from %s: %s", in.From, in.Text)
 			}
+			if (in.Type == "private" || in.Type == "group") && len(in.Attachments) > 0 {
+				fmt.Println("    " + formatAttachments(in.Attachments))
+			}
 		}
 	}()
 
 	fmt.Println("\nCommands:")
 	fmt.Println("/pm <user> <message>  -- private message")
 	fmt.Println("/group <group> <message> -- send to group")
-	fmt.Println("/create_group <group>  -- create group")
-	fmt.Println("/join_group <group>  -- join group")
+	fmt.Println("/create_group [workspace/]<group> [public|private|invite_only] [channel]  -- create group or announcement channel")
+	fmt.Println("/join_group <group>  -- join group (private groups: send a join request)")
 	fmt.Println("/my_groups  -- list of your groups")
+	fmt.Println("/groups [query] [+offset]  -- browse or search public groups")
+	fmt.Println("/workspaces  -- list your workspaces")
+	fmt.Println("/workspace_create <slug> [name]  -- create a workspace (server admins)")
+	fmt.Println("/workspace_add <workspace> <user>, /workspace_remove <workspace> <user>  -- add (server admins) or remove (workspace admins) workspace members")
+	fmt.Println("/promote <group> <user>  -- make a member group admin (owner only)")
+	fmt.Println("/demote <group> <user>  -- make a group admin a member (owner only)")
+	fmt.Println("/transfer_owner <group> <user>  -- hand group ownership to a member")
+	fmt.Println("/group_visibility <group> <public|private|invite_only>  -- change group visibility (owner only)")
+	fmt.Println("/group_edit <group> <field> <value>  -- edit name, display_name, topic, description, kind, post, invite or mention policy")
+	fmt.Println("/invite <group> <user>  -- invite a user to a group")
+	fmt.Println("/invites  -- list your pending invitations")
+	fmt.Println("/accept <id>, /decline <id>  -- answer an invitation")
+	fmt.Println("/join_requests <group>  -- list pending join requests (group admins)")
+	fmt.Println("/approve <id>, /reject <id>  -- review a join request")
+	fmt.Println("/invite_link <group> [expiry] [max_uses]  -- create a shareable invite code (e.g. 24h 10)")
+	fmt.Println("/invite_links <group>  -- list invite codes and who joined with them")
+	fmt.Println("/invite_revoke <code>  -- revoke an invite code")
+	fmt.Println("/redeem <code>  -- join a group with an invite code")
+	fmt.Println("/leave <group>  -- leave a group")
+	fmt.Println("/kick <group> <user> [reason]  -- remove a member (group admins)")
+	fmt.Println("/ban <group> <user> [duration] [reason]  -- ban a user, e.g. /ban team bob 24h spam")
+	fmt.Println("/unban <group> <user>, /bans <group>  -- lift or list bans")
+	fmt.Println("/history <group|@user> [limit]  -- show message history")
+	fmt.Println("/inbox [+offset]  -- your recent conversations with last message and unread counts")
+	fmt.Println("/mute <@user|group> [duration], /unmute <@user|group>  -- mute a conversation")
+	fmt.Println("/mentions [unread] [+before_id]  -- group messages that mention you")
+	fmt.Println("/find <words> [from:user] [in:@user|group] [after:YYYY-MM-DD] [before:YYYY-MM-DD]  -- search your messages")
+	fmt.Println("/read <@user|group> [id]  -- mark a conversation read (up to a message)")
+	fmt.Println("/receipts [on|off]  -- show or change whether others see your read receipts")
+	fmt.Println("/typing <@user|group> [stop]  -- show that you are typing")
+	fmt.Println("/reply <id> <message>  -- reply in the thread of a message")
+	fmt.Println("/send_file <@user|group> <path> [caption]  -- send a file or image")
+	fmt.Println("/schedule <@user|group> <30m|15:04|2006-01-02T15:04> <message>  -- send a message later")
+	fmt.Println("/schedule list, /schedule cancel <id>  -- your pending scheduled messages")
+	fmt.Println("/download <file_id> [path]  -- save a file sent to you")
+	fmt.Println("/thread <id> [+after_id]  -- show a thread")
+	fmt.Println("/react <id> <emoji>, /unreact <id> <emoji>  -- react to a message")
+	fmt.Println("/edit <id> <text>, /delete <id>  -- edit or delete a message you sent (group admins can delete any)")
+	fmt.Println("/edits <id>  -- show earlier versions of a message")
+	fmt.Println("/pin <id>, /unpin <id>  -- pin a message in its conversation (group admins in groups)")
+	fmt.Println("/pins <@user|group>  -- list pinned messages")
+	fmt.Println("/disappear <@user|group> [off|<duration> [read]]  -- show or set disappearing messages")
 	fmt.Println("/list_users  -- list of online users")
 	fmt.Println("/search <query>  -- search users (fuzzy search)")
+	fmt.Println("/passwd <old> <new>  -- change your password")
+	fmt.Println("/sessions  -- list your active sessions")
+	fmt.Println("/revoke <session_id>  -- revoke one of your sessions")
+	fmt.Println("/create_bot <name> [display name]  -- create a bot account you own")
+	fmt.Println("/apikey_create <name> [scopes] [bot]  -- create an api key (scopes: read,chat,groups)")
+	fmt.Println("/apikeys [bot]  -- list api keys")
+	fmt.Println("/apikey_revoke <key_id>  -- revoke an api key")
+	fmt.Println("/admin <command>  -- administration (moderators and admins)")
+	fmt.Println("/quit  -- log out and exit")
 
 	// Read stdin commands
 	for {
@@ -163,21 +320,77 @@ func main() {
 			} else {
 				logger.Printf("Sent group message to %s: %s", parts[1], parts[2])
 			}
+		} else if strings.HasPrefix(line, "/typing ") {
+			parts := strings.Fields(line)
+			if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "stop") {
+				fmt.Println("usage /typing <@user|group> [stop]")
+				continue
+			}
+			msg := &pb.ChatMessage{From: username, To: parts[1], Type: "typing", ChatType: "group", Text: "start"}
+			if strings.HasPrefix(parts[1], "@") {
+				msg.To, msg.ChatType = strings.TrimPrefix(parts[1], "@"), "private"
+			}
+			if len(parts) == 3 {
+				msg.Text = "stop"
+			}
+			if err := stream.Send(msg); err != nil {
+				logger.Printf("Error sending typing event to %s: %v", parts[1], err)
+				fmt.Println("send error:", err)
+			}
+		} else if strings.HasPrefix(line, "/reply ") {
+			parts := strings.SplitN(line, " ", 3)
+			var id int64
+			if len(parts) == 3 {
+				id, _ = strconv.ParseInt(strings.TrimPrefix(parts[1], "#"), 10, 64)
+			}
+			if id <= 0 {
+				fmt.Println("usage /reply <message_id> <message>")
+				continue
+			}
+			// Server gửi reply vào conversation của message gốc
+			msg := &pb.ChatMessage{
+				From:      username,
+				ReplyTo:   id,
+				Text:      parts[2],
+				Timestamp: time.Now().Unix(),
+			}
+			if err := stream.Send(msg); err != nil {
+				logger.Printf("Error sending reply to %d: %v", id, err)
+				fmt.Println("send error:", err)
+			} else {
+				logger.Printf("Sent reply to %d: %s", id, parts[2])
+			}
 		} else if strings.HasPrefix(line, "/create_group ") {
-			parts := strings.SplitN(line, " ", 2)
-			if len(parts) < 2 {
-				fmt.Println("usage /create_group <group>")
+			parts := strings.Fields(line)
+			if len(parts) < 2 || len(parts) > 4 {
+				fmt.Println("usage /create_group [workspace/]<group> [public|private|invite_only] [channel]")
 				continue
 			}
-			grp := parts[1]
+			grp, workspace := parts[1], ""
+			if ws, name, ok := strings.Cut(grp, "/"); ok {
+				workspace, grp = ws, name
+			}
+			visibility, kind := "", ""
+			for _, opt := range parts[2:] {
+				if opt == "channel" || opt == "group" {
+					kind = opt
+				} else {
+					visibility = opt
+				}
+			}
 			logger.Printf("Creating group: %s", grp)
-			_, err := client.CreateGroup(context.Background(), &pb.CreateGroupRequest{
-				GroupName: grp,
-				Members:   []string{username}, // Thêm creator vào group
+			res, err := client.CreateGroup(ctx, &pb.CreateGroupRequest{
+				GroupName:  grp,
+				Members:    []string{username}, // Thêm creator vào group
+				Visibility: visibility,
+				Kind:       kind,
+				Workspace:  workspace,
 			})
 			if err != nil {
 				logger.Printf("Error creating group %s: %v", grp, err)
 				fmt.Println("create group err:", err)
+			} else if !res.Ok {
+				fmt.Println("create group failed:", res.Message)
 			} else {
 				logger.Printf("Group created successfully: %s", grp)
 				fmt.Printf("Group '%s' created and you've joined it!\n", grp)
@@ -190,17 +403,19 @@ func main() {
 			}
 			grp := parts[1]
 			logger.Printf("Joining group: %s", grp)
-			_, err := client.JoinGroup(context.Background(), &pb.JoinGroupRequest{GroupName: grp, Username: username})
+			res, err := client.JoinGroup(ctx, &pb.JoinGroupRequest{GroupName: grp, Username: username})
 			if err != nil {
 				logger.Printf("Error joining group %s: %v", grp, err)
 				fmt.Println("join group err:", err)
+			} else if !res.Ok {
+				fmt.Println("join group failed:", res.Message)
 			} else {
-				logger.Printf("Joined group successfully: %s", grp)
-				fmt.Println("joined group:", grp)
+				logger.Printf("Join group %s: %s", grp, res.Message)
+				fmt.Printf("%s: %s\n", grp, res.Message)
 			}
 		} else if line == "/my_groups" {
 			logger.Println("Requesting user groups list")
-			res, err := client.GetUserGroups(context.Background(), &pb.GetUserGroupsRequest{Username: username})
+			res, err := client.GetUserGroups(ctx, &pb.GetUserGroupsRequest{Username: username})
 			if err != nil {
 				logger.Printf("Error getting groups: %v", err)
 				fmt.Println("get groups err:", err)
@@ -211,13 +426,42 @@ func main() {
 				} else {
 					fmt.Printf("Your groups (%d):\n", len(res.Groups))
 					for _, grp := range res.Groups {
-						fmt.Printf("  - %s (%d members)\n", grp.Name, len(grp.Members))
+						fmt.Printf("  - %s/%s \"%s\" [%s %s] (%d members, owner: %s, you: %s, %d unread)\n", grp.Workspace, grp.Name, grp.DisplayName, grp.Visibility, grp.Kind, len(grp.Members), grp.Owner, grp.MyRole, grp.UnreadCount)
+						if grp.Topic != "" {
+							fmt.Printf("      topic: %s\n", grp.Topic)
+						}
 					}
 				}
 			}
+		} else if strings.HasPrefix(line, "/promote ") || strings.HasPrefix(line, "/demote ") || strings.HasPrefix(line, "/transfer_owner ") {
+			parts := strings.Fields(line)
+			if len(parts) != 3 {
+				fmt.Printf("usage %s <group> <user>\n", parts[0])
+				continue
+			}
+			req := &pb.GroupMemberRequest{GroupName: parts[1], Username: parts[2]}
+			logger.Printf("Group role change %s: %s in %s", parts[0], parts[2], parts[1])
+			var (
+				res *pb.GroupActionResponse
+				err error
+			)
+			switch parts[0] {
+			case "/promote":
+				res, err = client.PromoteMember(ctx, req)
+			case "/demote":
+				res, err = client.DemoteMember(ctx, req)
+			default:
+				res, err = client.TransferOwnership(ctx, req)
+			}
+			if err != nil {
+				logger.Printf("Error in %s: %v", parts[0], err)
+				fmt.Println("group role err:", err)
+			} else {
+				fmt.Println(res.Message)
+			}
 		} else if line == "/list_users" {
 			logger.Println("Requesting online users list")
-			res, err := client.ListUsers(context.Background(), &pb.Empty{})
+			res, err := client.ListUsers(ctx, &pb.Empty{})
 			if err != nil {
 				logger.Printf("Error listing users: %v", err)
 				fmt.Println("list users err:", err)
@@ -230,6 +474,8 @@ func main() {
 					for _, u := range res.Users {
 						if u.Username == username {
 							fmt.Printf("  - %s (you)\n", u.Username)
+						} else if u.IsBot {
+							fmt.Printf("  - %s [bot]\n", u.Username)
 						} else {
 							fmt.Printf("  - %s\n", u.Username)
 						}
@@ -244,7 +490,7 @@ func main() {
 			}
 			query := parts[1]
 			logger.Printf("Searching users with query: %s", query)
-			res, err := client.SearchUsers(context.Background(), &pb.SearchUsersRequest{
+			res, err := client.SearchUsers(ctx, &pb.SearchUsersRequest{
 				Query: query,
 				Limit: 20,
 			})
@@ -262,6 +508,9 @@ func main() {
 						if u.IsOnline {
 							onlineStatus = "online"
 						}
+						if u.IsBot {
+							onlineStatus += ", bot"
+						}
 						displayName := u.DisplayName
 						if displayName == "" {
 							displayName = u.Username
@@ -274,6 +523,173 @@ func main() {
 					}
 				}
 			}
+		} else if strings.HasPrefix(line, "/passwd ") {
+			parts := strings.Fields(line)
+			if len(parts) != 3 {
+				fmt.Println("usage /passwd <old> <new>")
+				continue
+			}
+			logger.Println("Changing password")
+			res, err := client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+				Username:    username,
+				OldPassword: parts[1],
+				NewPassword: parts[2],
+			})
+			if err != nil {
+				logger.Printf("Error changing password: %v", err)
+				fmt.Println("change password err:", err)
+			} else if !res.Ok {
+				logger.Printf("Password change rejected: %s", res.Message)
+				fmt.Println("change password failed:", res.Message)
+			} else {
+				logger.Println("Password changed")
+				fmt.Println("Password changed.")
+			}
+		} else if line == "/sessions" {
+			logger.Println("Requesting sessions list")
+			res, err := client.ListSessions(ctx, &pb.Empty{})
+			if err != nil {
+				logger.Printf("Error listing sessions: %v", err)
+				fmt.Println("list sessions err:", err)
+			} else {
+				fmt.Printf("Active sessions (%d):\n", len(res.Sessions))
+				for _, sess := range res.Sessions {
+					created := time.Unix(sess.CreatedAt, 0).Format("2006-01-02 15:04")
+					note := ""
+					if sess.Current {
+						note += " (this client)"
+					}
+					if sess.Connected {
+						note += " [connected]"
+					}
+					fmt.Printf("  - #%d since %s%s\n", sess.Id, created, note)
+				}
+			}
+		} else if strings.HasPrefix(line, "/revoke ") {
+			parts := strings.Fields(line)
+			if len(parts) != 2 {
+				fmt.Println("usage /revoke <session_id>")
+				continue
+			}
+			id, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				fmt.Println("invalid session id:", parts[1])
+				continue
+			}
+			logger.Printf("Revoking session %d", id)
+			res, err := client.RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: id})
+			if err != nil {
+				logger.Printf("Error revoking session %d: %v", id, err)
+				fmt.Println("revoke err:", err)
+			} else {
+				fmt.Println(res.Message)
+			}
+		} else if strings.HasPrefix(line, "/create_bot ") {
+			parts := strings.SplitN(line, " ", 3)
+			if len(parts) < 2 {
+				fmt.Println("usage /create_bot <name> [display name]")
+				continue
+			}
+			req := &pb.CreateBotRequest{Username: parts[1]}
+			if len(parts) == 3 {
+				req.DisplayName = parts[2]
+			}
+			logger.Printf("Creating bot: %s", req.Username)
+			res, err := client.CreateBot(ctx, req)
+			if err != nil {
+				logger.Printf("Error creating bot %s: %v", req.Username, err)
+				fmt.Println("create bot err:", err)
+			} else {
+				fmt.Println(res.Message)
+			}
+		} else if strings.HasPrefix(line, "/apikey_create ") {
+			parts := strings.Fields(line)
+			if len(parts) < 2 || len(parts) > 4 {
+				fmt.Println("usage /apikey_create <name> [scopes] [bot]")
+				continue
+			}
+			req := &pb.CreateApiKeyRequest{Name: parts[1]}
+			if len(parts) >= 3 {
+				req.Scopes = strings.Split(parts[2], ",")
+			}
+			if len(parts) == 4 {
+				req.Username = parts[3]
+			}
+			logger.Printf("Creating api key: %s", req.Name)
+			res, err := client.CreateApiKey(ctx, req)
+			if err != nil {
+				logger.Printf("Error creating api key %s: %v", req.Name, err)
+				fmt.Println("create api key err:", err)
+			} else if !res.Ok {
+				fmt.Println("create api key failed:", res.Message)
+			} else {
+				logger.Printf("Api key %d created", res.Info.Id)
+				fmt.Println(res.Message)
+				fmt.Printf("  key #%d for %s: %s\n", res.Info.Id, res.Info.Username, res.Key)
+			}
+		} else if line == "/apikeys" || strings.HasPrefix(line, "/apikeys ") {
+			req := &pb.ListApiKeysRequest{}
+			if parts := strings.Fields(line); len(parts) == 2 {
+				req.Username = parts[1]
+			}
+			res, err := client.ListApiKeys(ctx, req)
+			if err != nil {
+				logger.Printf("Error listing api keys: %v", err)
+				fmt.Println("list api keys err:", err)
+			} else if len(res.Keys) == 0 {
+				fmt.Println("No api keys.")
+			} else {
+				fmt.Printf("Api keys (%d):\n", len(res.Keys))
+				for _, k := range res.Keys {
+					lastUsed := "never"
+					if k.LastUsedAt > 0 {
+						lastUsed = time.Unix(k.LastUsedAt, 0).Format("2006-01-02 15:04")
+					}
+					fmt.Printf("  - #%d %s (%s...) as %s [%s] last used: %s\n",
+						k.Id, k.Name, k.Prefix, k.Username, strings.Join(k.Scopes, ","), lastUsed)
+				}
+			}
+		} else if strings.HasPrefix(line, "/apikey_revoke ") {
+			parts := strings.Fields(line)
+			if len(parts) != 2 {
+				fmt.Println("usage /apikey_revoke <key_id>")
+				continue
+			}
+			id, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				fmt.Println("invalid key id:", parts[1])
+				continue
+			}
+			logger.Printf("Revoking api key %d", id)
+			res, err := client.RevokeApiKey(ctx, &pb.RevokeApiKeyRequest{KeyId: id})
+			if err != nil {
+				logger.Printf("Error revoking api key %d: %v", id, err)
+				fmt.Println("revoke api key err:", err)
+			} else {
+				fmt.Println(res.Message)
+			}
+		} else if line == "/admin" || strings.HasPrefix(line, "/admin ") {
+			runAdminCommand(ctx, admin, logger, line)
+		} else if runGroupCommand(ctx, client, logger, line) {
+			// group membership / history commands
+		} else if runMessageCommand(ctx, client, logger, line) {
+			// edit / delete sent messages
+		} else if runConversationCommand(ctx, client, logger, line) {
+			// read state / inbox
+		} else if runFileCommand(ctx, client, stream, username, logger, line) {
+			// file upload / download
+		} else if runScheduleCommand(ctx, client, logger, line) {
+			// scheduled messages
+		} else if runDisappearCommand(ctx, client, logger, line) {
+			// disappearing messages
+		} else if line == "/quit" {
+			logger.Println("Logging out")
+			if _, err := client.Logout(ctx, &pb.Empty{}); err != nil {
+				logger.Printf("Error logging out: %v", err)
+			}
+			stream.CloseSend()
+			fmt.Println("Bye!")
+			return
 		} else {
 			fmt.Println("unknown command")
 		}
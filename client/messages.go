@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+// messageCommands lists the commands handled by runMessageCommand with their usage
+var messageCommands = map[string]string{
+	"/edit":    "/edit <message_id> <new text>",
+	"/delete":  "/delete <message_id>",
+	"/edits":   "/edits <message_id>",
+	"/thread":  "/thread <message_id> [+after_id]",
+	"/react":   "/react <message_id> <emoji>",
+	"/unreact": "/unreact <message_id> <emoji>",
+	"/pin":     "/pin <message_id>",
+	"/unpin":   "/unpin <message_id>",
+}
+
+// conversationCommands lists the commands handled by runConversationCommand with their usage
+var conversationCommands = map[string]string{
+	"/read":     "/read <@user|group> [message_id]",
+	"/receipts": "/receipts [on|off]",
+	"/inbox":    "/inbox [+offset]",
+	"/mute":     "/mute <@user|group> [duration e.g. 8h]",
+	"/unmute":   "/unmute <@user|group>",
+	"/mentions": "/mentions [unread] [+before_id]",
+	"/pins":     "/pins <@user|group>",
+	"/find":     "/find <words> [from:user] [in:@user|group] [after:YYYY-MM-DD] [before:YYYY-MM-DD] [+offset]",
+}
+
+// runConversationCommand handles read state and conversation list commands.
+// It returns false when line is not one of them.
+func runConversationCommand(ctx context.Context, client pb.ChatServiceClient, logger *log.Logger, line string) bool {
+	parts := strings.Fields(line)
+	usage, ok := conversationCommands[parts[0]]
+	if !ok {
+		return false
+	}
+
+	logger.Printf("Conversation command: %s", line)
+	switch parts[0] {
+	case "/read":
+		if len(parts) < 2 || len(parts) > 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.MarkReadRequest{ChatType: "group", Target: parts[1]}
+		if strings.HasPrefix(parts[1], "@") {
+			req.ChatType, req.Target = "private", strings.TrimPrefix(parts[1], "@")
+		}
+		if len(parts) == 3 {
+			id, err := strconv.ParseInt(strings.TrimPrefix(parts[2], "#"), 10, 64)
+			if err != nil {
+				fmt.Println("usage", usage)
+				return true
+			}
+			req.MessageId = id
+		}
+		res, err := client.MarkRead(ctx, req)
+		if err != nil {
+			fmt.Println("read err:", err)
+			return true
+		}
+		fmt.Println(res.Message)
+	case "/receipts":
+		req := &pb.UpdateSettingsRequest{}
+		if len(parts) == 2 {
+			if parts[1] != "on" && parts[1] != "off" {
+				fmt.Println("usage", usage)
+				return true
+			}
+			enabled := parts[1] == "on"
+			req.ReadReceipts = &enabled
+		}
+		res, err := client.UpdateSettings(ctx, req)
+		if err != nil {
+			fmt.Println("settings err:", err)
+			return true
+		}
+		if !res.Ok {
+			fmt.Println(res.Message)
+			return true
+		}
+		state := "off"
+		if res.ReadReceipts {
+			state = "on"
+		}
+		fmt.Println("Read receipts:", state)
+	case "/mute", "/unmute":
+		if len(parts) < 2 || len(parts) > 3 || (parts[0] == "/unmute" && len(parts) == 3) {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.MuteRequest{ChatType: "group", Target: parts[1], Mute: parts[0] == "/mute"}
+		if strings.HasPrefix(parts[1], "@") {
+			req.ChatType, req.Target = "private", strings.TrimPrefix(parts[1], "@")
+		}
+		if len(parts) == 3 {
+			d, err := time.ParseDuration(parts[2])
+			if err != nil {
+				fmt.Println("usage", usage)
+				return true
+			}
+			req.DurationSeconds = int64(d.Seconds())
+		}
+		res, err := client.MuteConversation(ctx, req)
+		if err != nil {
+			fmt.Println("mute err:", err)
+			return true
+		}
+		fmt.Println(res.Message)
+	case "/inbox":
+		req := &pb.ListConversationsRequest{}
+		if len(parts) == 2 && strings.HasPrefix(parts[1], "+") {
+			n, err := strconv.ParseInt(parts[1][1:], 10, 32)
+			if err != nil {
+				fmt.Println("usage", usage)
+				return true
+			}
+			req.Offset = int32(n)
+		}
+		list, err := client.ListConversations(ctx, req)
+		if err != nil {
+			fmt.Println("inbox err:", err)
+			return true
+		}
+		if len(list.Conversations) == 0 {
+			fmt.Println("No conversations yet.")
+			return true
+		}
+		for _, c := range list.Conversations {
+			name := c.Target
+			if c.ChatType == "private" {
+				name = "@" + c.Target
+			}
+			flags := ""
+			if c.UnreadCount > 0 {
+				flags += fmt.Sprintf(" (%d unread)", c.UnreadCount)
+			}
+			if c.Muted {
+				flags += " [muted]"
+			}
+			fmt.Printf("  - %s%s  %s\n", name, flags, time.Unix(c.LastActivityAt, 0).Format("01-02 15:04"))
+			if c.LastMessageId != 0 {
+				fmt.Printf("      %s: %s\n", c.LastMessageFrom, c.LastMessagePreview)
+			}
+		}
+		if list.NextOffset > 0 {
+			fmt.Printf("More: /inbox +%d\n", list.NextOffset)
+		}
+	case "/pins":
+		if len(parts) != 2 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.ListPinsRequest{ChatType: "group", Target: parts[1]}
+		if strings.HasPrefix(parts[1], "@") {
+			req.ChatType, req.Target = "private", strings.TrimPrefix(parts[1], "@")
+		}
+		list, err := client.ListPins(ctx, req)
+		if err != nil {
+			fmt.Println("pins err:", err)
+			return true
+		}
+		if !list.Ok {
+			fmt.Println(list.Message)
+			return true
+		}
+		if len(list.Pins) == 0 {
+			fmt.Println("No pinned messages.")
+			return true
+		}
+		for _, p := range list.Pins {
+			fmt.Printf("  %s  (pinned by %s at %s)\n", formatStored(p.Message), p.PinnedBy, time.Unix(p.PinnedAt, 0).Format("01-02 15:04"))
+		}
+	case "/mentions":
+		req := &pb.ListMentionsRequest{}
+		for _, arg := range parts[1:] {
+			switch {
+			case arg == "unread":
+				req.UnreadOnly = true
+			case strings.HasPrefix(arg, "+"):
+				id, err := strconv.ParseInt(arg[1:], 10, 64)
+				if err != nil {
+					fmt.Println("usage", usage)
+					return true
+				}
+				req.BeforeId = id
+			default:
+				fmt.Println("usage", usage)
+				return true
+			}
+		}
+		list, err := client.ListMentions(ctx, req)
+		if err != nil {
+			fmt.Println("mentions err:", err)
+			return true
+		}
+		if len(list.Mentions) == 0 {
+			fmt.Println("No mentions.")
+			return true
+		}
+		for _, item := range list.Mentions {
+			flag := ""
+			if item.Unread {
+				flag = " (unread)"
+			}
+			if item.Kind != "user" {
+				flag += " @" + item.Kind
+			}
+			fmt.Printf("  %s %s%s\n", item.Message.To, formatStored(item.Message), flag)
+		}
+		if list.NextBeforeId > 0 {
+			more := "/mentions"
+			if req.UnreadOnly {
+				more += " unread"
+			}
+			fmt.Printf("More: %s +%d\n", more, list.NextBeforeId)
+		}
+	case "/find":
+		req, ok := parseFind(parts[1:])
+		if !ok {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err := client.SearchMessages(ctx, req)
+		if err != nil {
+			fmt.Println("find err:", err)
+			return true
+		}
+		if !res.Ok {
+			fmt.Println(res.Message)
+			return true
+		}
+		if len(res.Results) == 0 {
+			fmt.Println("No messages found.")
+			return true
+		}
+		for _, r := range res.Results {
+			m := r.Message
+			where := m.To
+			if m.Type == "private" {
+				where = m.From + " -> @" + m.To
+			}
+			fmt.Printf("  [%s] #%d %s [%s]: %s\n", time.Unix(m.Timestamp, 0).Format("2006-01-02 15:04"), m.Id, where, m.From, r.Snippet)
+		}
+		if res.NextOffset > 0 {
+			fmt.Printf("More: %s +%d\n", strings.Join(findQueryArgs(parts[1:]), " "), res.NextOffset)
+		}
+	}
+	return true
+}
+
+// parseFind builds a search request from the arguments of /find; filters are
+// written as from:, in:, after: and before:, every other word is search text
+func parseFind(args []string) (*pb.SearchMessagesRequest, bool) {
+	req := &pb.SearchMessagesRequest{}
+	var words []string
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, ":")
+		switch {
+		case found && key == "from" && value != "":
+			req.From = strings.TrimPrefix(value, "@")
+		case found && key == "in" && value != "":
+			req.ChatType, req.Target = "group", value
+			if strings.HasPrefix(value, "@") {
+				req.ChatType, req.Target = "private", strings.TrimPrefix(value, "@")
+			}
+		case found && (key == "after" || key == "before"):
+			day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return nil, false
+			}
+			if key == "after" {
+				req.After = day.Unix()
+			} else {
+				req.Before = day.Unix()
+			}
+		case strings.HasPrefix(arg, "+"):
+			n, err := strconv.ParseInt(arg[1:], 10, 32)
+			if err != nil {
+				return nil, false
+			}
+			req.Offset = int32(n)
+		default:
+			words = append(words, arg)
+		}
+	}
+	req.Query = strings.Join(words, " ")
+	return req, req.Query != ""
+}
+
+// findQueryArgs returns the /find command without its +offset, to print the next page command
+func findQueryArgs(args []string) []string {
+	out := []string{"/find"}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "+") {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// formatReactions renders aggregated reactions, e.g. "👍 3 🎉 1"
+func formatReactions(reactions []*pb.ReactionCount) string {
+	parts := make([]string, 0, len(reactions))
+	for _, r := range reactions {
+		parts = append(parts, fmt.Sprintf("%s %d", r.Emoji, r.Count))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatStored renders a message loaded from history, with its ID so it can be edited
+func formatStored(m *pb.ChatMessage) string {
+	ts := time.Unix(m.Timestamp, 0).Format("01-02 15:04:05")
+	switch {
+	case m.Deleted:
+		return fmt.Sprintf("[%s] #%d [%s]: (message deleted)", ts, m.Id, m.From)
+	case expired(m, time.Now()):
+		// Chưa bị server xóa nhưng đã hết hạn: ẩn nội dung
+		return fmt.Sprintf("[%s] #%d [%s]: (message expired)", ts, m.Id, m.From)
+	case m.Type == "system":
+		return fmt.Sprintf("[%s] #%d * %s", ts, m.Id, m.Text)
+	}
+	line := fmt.Sprintf("[%s] #%d [%s]: %s", ts, m.Id, m.From, m.Text)
+	if m.EditedAt > 0 {
+		line += " (edited)"
+	}
+	line += expiryMark(m, time.Now())
+	if len(m.Reactions) > 0 {
+		line += " {" + formatReactions(m.Reactions) + "}"
+	}
+	if len(m.Attachments) > 0 {
+		line += " " + formatAttachments(m.Attachments)
+	}
+	if m.ReplyCount > 0 {
+		line += fmt.Sprintf(" [%d replies, last by %s at %s]", m.ReplyCount, m.LastReplyBy, time.Unix(m.LastReplyAt, 0).Format("01-02 15:04"))
+	}
+	return line
+}
+
+// runMessageCommand handles commands acting on a sent message.
+// It returns false when line is not one of them.
+func runMessageCommand(ctx context.Context, client pb.ChatServiceClient, logger *log.Logger, line string) bool {
+	parts := strings.SplitN(line, " ", 3)
+	usage, ok := messageCommands[parts[0]]
+	if !ok {
+		return false
+	}
+	if len(parts) < 2 {
+		fmt.Println("usage", usage)
+		return true
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(parts[1], "#"), 10, 64)
+	if err != nil {
+		fmt.Println("usage", usage)
+		return true
+	}
+
+	logger.Printf("Message command: %s", line)
+	var res *pb.MessageActionResponse
+	switch parts[0] {
+	case "/edit":
+		if len(parts) < 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		res, err = client.EditMessage(ctx, &pb.EditMessageRequest{MessageId: id, Text: parts[2]})
+	case "/delete":
+		res, err = client.DeleteMessage(ctx, &pb.MessageIdRequest{MessageId: id})
+	case "/pin":
+		res, err = client.PinMessage(ctx, &pb.MessageIdRequest{MessageId: id})
+	case "/unpin":
+		res, err = client.UnpinMessage(ctx, &pb.MessageIdRequest{MessageId: id})
+	case "/react", "/unreact":
+		if len(parts) < 3 {
+			fmt.Println("usage", usage)
+			return true
+		}
+		req := &pb.ReactionRequest{MessageId: id, Emoji: strings.TrimSpace(parts[2])}
+		if parts[0] == "/react" {
+			res, err = client.AddReaction(ctx, req)
+		} else {
+			res, err = client.RemoveReaction(ctx, req)
+		}
+	case "/thread":
+		req := &pb.GetThreadRequest{MessageId: id}
+		if len(parts) == 3 && strings.HasPrefix(parts[2], "+") {
+			req.AfterId, _ = strconv.ParseInt(parts[2][1:], 10, 64)
+		}
+		thread, err := client.GetThread(ctx, req)
+		if err != nil {
+			fmt.Println("thread err:", err)
+			return true
+		}
+		if !thread.Ok {
+			fmt.Println(thread.Message)
+			return true
+		}
+		if req.AfterId == 0 {
+			fmt.Println(formatStored(thread.Root))
+		}
+		for _, r := range thread.Replies {
+			fmt.Println("    " + formatStored(r))
+		}
+		if thread.NextAfterId > 0 {
+			fmt.Printf("More: /thread %d +%d\n", thread.Root.Id, thread.NextAfterId)
+		}
+		return true
+	case "/edits":
+		list, err := client.GetMessageEdits(ctx, &pb.MessageIdRequest{MessageId: id})
+		if err != nil {
+			fmt.Println("edits err:", err)
+			return true
+		}
+		if !list.Ok {
+			fmt.Println(list.Message)
+			return true
+		}
+		if len(list.Edits) == 0 {
+			fmt.Println("Message was never edited.")
+			return true
+		}
+		for _, e := range list.Edits {
+			fmt.Printf("  [%s] before edit by %s: %s\n", time.Unix(e.EditedAt, 0).Format("01-02 15:04:05"), e.EditedBy, e.OldText)
+		}
+		return true
+	}
+
+	if err != nil {
+		logger.Printf("Message command %s failed: %v", parts[0], err)
+		fmt.Println("message err:", err)
+	} else {
+		fmt.Println(res.Message)
+	}
+	return true
+}
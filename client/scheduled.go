@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "chat-grpc/proto"
+)
+
+// parseSendAt reads when a scheduled message should go out: a delay like
+// "30m" or "2h", a local time "2006-01-02T15:04", or "15:04" (today, or
+// tomorrow when that time has passed)
+func parseSendAt(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("delay must be positive")
+		}
+		return now.Add(d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("15:04", s, time.Local); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use 30m, 15:04 or 2006-01-02T15:04", s)
+}
+
+// formatScheduled renders one pending scheduled message
+func formatScheduled(sm *pb.ScheduledMessageInfo) string {
+	to := sm.Target
+	if sm.ChatType == "private" {
+		to = "@" + to
+	}
+	return fmt.Sprintf("#%d %s -> %s: %s", sm.Id, time.Unix(sm.SendAt, 0).Format("2006-01-02 15:04"), to, sm.Text)
+}
+
+// runScheduleCommand handles /schedule, /schedule list and /schedule cancel.
+// It returns false when line is not one of them.
+func runScheduleCommand(ctx context.Context, client pb.ChatServiceClient, logger *log.Logger, line string) bool {
+	if line != "/schedule" && !strings.HasPrefix(line, "/schedule ") {
+		return false
+	}
+	parts := strings.Fields(line)
+
+	switch {
+	case len(parts) == 2 && parts[1] == "list":
+		res, err := client.ListScheduledMessages(ctx, &pb.Empty{})
+		if err != nil {
+			logger.Printf("Error listing scheduled messages: %v", err)
+			fmt.Println("schedule list err:", err)
+			return true
+		}
+		if len(res.Scheduled) == 0 {
+			fmt.Println("No scheduled messages")
+			return true
+		}
+		for _, sm := range res.Scheduled {
+			fmt.Println(formatScheduled(sm))
+		}
+	case len(parts) == 3 && parts[1] == "cancel":
+		id, _ := strconv.ParseInt(strings.TrimPrefix(parts[2], "#"), 10, 64)
+		if id <= 0 {
+			fmt.Println("usage /schedule cancel <id>")
+			return true
+		}
+		res, err := client.CancelScheduledMessage(ctx, &pb.CancelScheduledMessageRequest{ScheduleId: id})
+		if err != nil {
+			logger.Printf("Error canceling scheduled message %d: %v", id, err)
+			fmt.Println("schedule cancel err:", err)
+			return true
+		}
+		fmt.Println(res.Message)
+	default:
+		parts = strings.SplitN(line, " ", 4)
+		if len(parts) < 4 {
+			fmt.Println("usage /schedule <@user|group> <30m|15:04|2006-01-02T15:04> <message>, /schedule list, /schedule cancel <id>")
+			return true
+		}
+		sendAt, err := parseSendAt(parts[2], time.Now())
+		if err != nil {
+			fmt.Println(err)
+			return true
+		}
+		req := &pb.ScheduleMessageRequest{ChatType: "group", Target: parts[1], Text: parts[3], SendAt: sendAt.Unix()}
+		if strings.HasPrefix(parts[1], "@") {
+			req.ChatType, req.Target = "private", strings.TrimPrefix(parts[1], "@")
+		}
+		res, err := client.ScheduleMessage(ctx, req)
+		if err != nil {
+			logger.Printf("Error scheduling message to %s: %v", parts[1], err)
+			fmt.Println("schedule err:", err)
+			return true
+		}
+		if res.Ok {
+			logger.Printf("Scheduled message %d to %s", res.Scheduled.Id, parts[1])
+		}
+		fmt.Println(res.Message)
+	}
+	return true
+}
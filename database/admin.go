@@ -0,0 +1,252 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Server-wide roles of a user
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// ValidRole reports whether role is a known server role
+func ValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// RoleAtLeast reports whether role has at least the privileges of min
+func RoleAtLeast(role, min string) bool {
+	return roleRanks[role] >= roleRanks[min]
+}
+
+// AuditLog model for GORM (administrative actions)
+type AuditLog struct {
+	ID        uint      `gorm:"primaryKey"`
+	Actor     string    `gorm:"size:50;not null;index"`
+	Action    string    `gorm:"size:50;not null;index"`
+	Target    string    `gorm:"size:100"`
+	Details   string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
+
+// TableName specifies the table name
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// RecordAudit appends an entry to the audit log
+func (db *DB) RecordAudit(actor, action, target, details string) error {
+	return db.Create(&AuditLog{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	}).Error
+}
+
+// ListAuditLog returns audit entries newest first, optionally filtered.
+// beforeID > 0 pages backwards from that entry.
+func (db *DB) ListAuditLog(actor, action string, beforeID uint, limit int) ([]AuditLog, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := db.Model(&AuditLog{})
+	if actor != "" {
+		query = query.Where("actor = ?", actor)
+	}
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var entries []AuditLog
+	if err := query.Order("id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListUsersPage lists users for administration, optionally filtered by a
+// username/display name substring or by disabled state
+func (db *DB) ListUsersPage(query string, disabledOnly bool, offset, limit int) ([]User, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	q := db.Model(&User{})
+	if query != "" {
+		q = q.Where("LOWER(username) LIKE LOWER(?) OR LOWER(display_name) LIKE LOWER(?)", "%"+query+"%", "%"+query+"%")
+	}
+	if disabledOnly {
+		q = q.Where("disabled_at IS NOT NULL")
+	}
+
+	var users []User
+	if err := q.Order("username ASC").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SetUserRole changes the server role of a user
+func (db *DB) SetUserRole(username, role string) error {
+	if !ValidRole(role) {
+		return errors.New("unknown role")
+	}
+	result := db.Model(&User{}).Where("username = ?", username).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetUserDisabled disables or re-enables an account. Disabling also revokes
+// every session of the user; the revoked session IDs are returned.
+func (db *DB) SetUserDisabled(username string, disabled bool) ([]uint, error) {
+	var disabledAt *time.Time
+	if disabled {
+		now := time.Now()
+		disabledAt = &now
+	}
+
+	result := db.Model(&User{}).Where("username = ?", username).Update("disabled_at", disabledAt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if !disabled {
+		return nil, nil
+	}
+	return db.RevokeUserSessions(username, 0)
+}
+
+// DeleteUser removes a user with its memberships, sessions, keys, reset tokens,
+// reactions, read cursors, mutes, mentions and scheduled messages.
+// Groups the user owned pass to a successor as in LeaveGroup, and groups left
+// empty are deleted. Messages are kept so conversation history stays readable.
+func (db *DB) DeleteUser(username string) error {
+	var emptied []uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if emptied, err = leaveGroups(tx, username, "role = ?", GroupRoleOwner); err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&GroupMember{}, &GroupInvitation{}, &GroupJoinRequest{}, &GroupBan{}, &WorkspaceMember{}, &Session{}, &APIKey{}, &PasswordReset{}, &MessageReaction{}, &ReadCursor{}, &ConversationMute{}, &Mention{}, &ScheduledMessage{}} {
+			if err := tx.Where("username = ?", username).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Where("username = ?", username).Delete(&User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return db.deleteGroups(emptied)
+}
+
+// DeleteGroup removes a group with its messages (and what refers to them),
+// members, invitations, join requests, invite codes, bans, read cursors, mutes,
+// retention and disappearing settings, and pending scheduled messages
+func (db *DB) DeleteGroup(groupID uint) error {
+	group, err := db.GetGroupByID(groupID)
+	if err != nil {
+		return err
+	}
+
+	// Messages go first in batches, like retention, so a large group is not one long transaction
+	for {
+		purged, err := db.purgeBatch("group_id = ?", []interface{}{group.ID}, purgeBatchSize, nil)
+		if err != nil {
+			return err
+		}
+		if len(purged) < purgeBatchSize {
+			break
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Messages sent meanwhile, or skipped while another purge held them
+		var ids []uint
+		if err := tx.Model(&Message{}).Where("group_id = ?", group.ID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := deleteMessageRows(tx, ids); err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{&GroupMember{}, &GroupInvitation{}, &GroupJoinRequest{}, &GroupInviteCode{}, &GroupBan{}, &ReadCursor{}, &ConversationMute{}, &RetentionPolicy{}} {
+			if err := tx.Where("group_id = ?", group.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("group_id = ? AND status = ?", group.ID, SchedulePending).Delete(&ScheduledMessage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("conversation = ?", PinConversation(group.ID, "", "")).Delete(&ConversationTTL{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(group).Error
+	})
+}
+
+// purgeBatchSize is how many messages one purge transaction deletes
+const purgeBatchSize = 500
+
+// PurgeMessages deletes messages sent by fromUser and/or to a group before a time,
+// in batches. At least one of fromUser and groupID must be set.
+func (db *DB) PurgeMessages(fromUser string, groupID uint, before time.Time) (int64, error) {
+	if fromUser == "" && groupID == 0 {
+		return 0, errors.New("purge requires a sender or a group")
+	}
+
+	where, args := "created_at < ?", []interface{}{before}
+	if fromUser != "" {
+		where += " AND from_user = ?"
+		args = append(args, fromUser)
+	}
+	if groupID != 0 {
+		where += " AND group_id = ?"
+		args = append(args, groupID)
+	}
+
+	var deleted int64
+	for {
+		purged, err := db.purgeBatch(where, args, purgeBatchSize, nil)
+		deleted += int64(len(purged))
+		if err != nil || len(purged) < purgeBatchSize {
+			return deleted, err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API key format: "ck_" + 48 hex chars. Prefix (first 11 chars) is kept for display.
+const (
+	apiKeyPrefix     = "ck_"
+	apiKeyShownChars = 11
+)
+
+// ErrInvalidAPIKey is returned when an API key is unknown or revoked
+var ErrInvalidAPIKey = errors.New("invalid or revoked api key")
+
+// APIKey model for GORM (long-lived scoped keys for bots and scripts)
+type APIKey struct {
+	ID         uint      `gorm:"primaryKey"`
+	Username   string    `gorm:"size:50;not null;index"` // user or bot the key acts as
+	CreatedBy  string    `gorm:"size:50;not null"`
+	Name       string    `gorm:"size:100;not null"`
+	Prefix     string    `gorm:"size:20;not null"`
+	KeyHash    string    `gorm:"size:64;uniqueIndex;not null"`
+	Scopes     string    `gorm:"size:255;not null"` // comma separated
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// TableName specifies the table name
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList splits the stored scopes
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// CreateBot creates a bot account owned by a user. Bots cannot log in with a password.
+func (db *DB) CreateBot(username, displayName, owner string) (*User, error) {
+	if displayName == "" {
+		displayName = username
+	}
+
+	bot := &User{
+		Username:    username,
+		Password:    "!", // không phải bcrypt hash nên không bao giờ khớp
+		DisplayName: displayName,
+		IsBot:       true,
+		BotOwner:    owner,
+		Role:        RoleUser,
+	}
+	if err := db.Create(bot).Error; err != nil {
+		return nil, err
+	}
+	return bot, nil
+}
+
+// CreateAPIKey issues a key acting as username and returns it with the plaintext key
+func (db *DB) CreateAPIKey(username, createdBy, name string, scopes []string) (*APIKey, string, error) {
+	secret, err := GenerateToken(24)
+	if err != nil {
+		return nil, "", err
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &APIKey{
+		Username:  username,
+		CreatedBy: createdBy,
+		Name:      name,
+		Prefix:    key[:apiKeyShownChars],
+		KeyHash:   HashToken(key),
+		Scopes:    strings.Join(scopes, ","),
+	}
+	if err := db.Create(apiKey).Error; err != nil {
+		return nil, "", err
+	}
+	return apiKey, key, nil
+}
+
+// GetActiveAPIKey looks up a non-revoked key and records its use
+func (db *DB) GetActiveAPIKey(key string) (*APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	var apiKey APIKey
+	result := db.Where("key_hash = ? AND revoked_at IS NULL", HashToken(key)).First(&apiKey)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, result.Error
+	}
+
+	now := time.Now()
+	db.Model(&apiKey).Update("last_used_at", now)
+	apiKey.LastUsedAt = &now
+	return &apiKey, nil
+}
+
+// ListAPIKeys returns the active keys acting as username
+func (db *DB) ListAPIKeys(username string) ([]APIKey, error) {
+	var keys []APIKey
+	result := db.Where("username = ? AND revoked_at IS NULL", username).Order("created_at DESC").Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return keys, nil
+}
+
+// GetAPIKey gets a key by ID
+func (db *DB) GetAPIKey(id uint) (*APIKey, error) {
+	var apiKey APIKey
+	result := db.First(&apiKey, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &apiKey, nil
+}
+
+// RevokeAPIKey revokes a key by ID
+func (db *DB) RevokeAPIKey(id uint) error {
+	result := db.Model(&APIKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidAPIKey
+	}
+	return nil
+}
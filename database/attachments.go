@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAttachmentNotFound is returned when an attachment does not exist
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// Attachment model for GORM (an uploaded file, linked to a message once sent)
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey"`
+	StorageKey  string    `gorm:"size:64;uniqueIndex;not null"` // key in the blob store
+	Uploader    string    `gorm:"size:50;not null;index"`
+	Filename    string    `gorm:"size:255;not null"`
+	ContentType string    `gorm:"size:100;not null"`
+	Size        int64     `gorm:"not null"`
+	SHA256      string    `gorm:"column:sha256;size:64;not null"`
+	MessageID   *uint     `gorm:"index"` // nil until sent, or after its message is deleted
+	Message     *Message  `gorm:"foreignKey:MessageID;constraint:OnDelete:SET NULL"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;index"`
+}
+
+// TableName specifies the table name
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// CreateAttachment records an uploaded file
+func (db *DB) CreateAttachment(attachment *Attachment) error {
+	return db.Create(attachment).Error
+}
+
+// GetAttachment loads an attachment by ID
+func (db *DB) GetAttachment(id uint) (*Attachment, error) {
+	var attachment Attachment
+	if err := db.First(&attachment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetPendingAttachments returns the attachments of ids uploaded by uploader
+// and not sent yet, in the order of ids. Missing ones are left out.
+func (db *DB) GetPendingAttachments(ids []uint, uploader string) ([]Attachment, error) {
+	var found []Attachment
+	if err := db.Where("id IN ? AND uploader = ? AND message_id IS NULL", ids, uploader).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]Attachment, len(found))
+	for _, a := range found {
+		byID[a.ID] = a
+	}
+	attachments := make([]Attachment, 0, len(found))
+	for _, id := range ids {
+		if a, ok := byID[id]; ok {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+// AttachToMessage links pending attachments of uploader to a sent message and
+// returns how many were linked
+func (db *DB) AttachToMessage(ids []uint, uploader string, messageID uint) (int64, error) {
+	result := db.Model(&Attachment{}).
+		Where("id IN ? AND uploader = ? AND message_id IS NULL", ids, uploader).
+		Update("message_id", messageID)
+	return result.RowsAffected, result.Error
+}
+
+// GetMessageAttachments returns the attachments of messages keyed by message ID
+func (db *DB) GetMessageAttachments(messageIDs []uint) (map[uint][]Attachment, error) {
+	out := make(map[uint][]Attachment)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+	var attachments []Attachment
+	if err := db.Where("message_id IN ?", messageIDs).Order("id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range attachments {
+		out[*a.MessageID] = append(out[*a.MessageID], a)
+	}
+	return out, nil
+}
+
+// DeleteStaleAttachments removes attachments not linked to any message and
+// uploaded before a time (never sent, or their message was deleted).
+// The storage keys of the removed rows are returned so their blobs can be deleted.
+// Select and delete are one statement, so an upload linked to a message
+// meanwhile is neither deleted nor returned.
+func (db *DB) DeleteStaleAttachments(before time.Time, limit int) ([]string, error) {
+	var keys []string
+	err := db.Raw(`
+		DELETE FROM attachments
+		WHERE id IN (
+			SELECT id FROM attachments
+			WHERE message_id IS NULL AND created_at < ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		) AND message_id IS NULL
+		RETURNING storage_key
+	`, before, limit).Scan(&keys).Error
+	return keys, err
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrBannedFromGroup is returned when a banned user tries to join a group
+var ErrBannedFromGroup = errors.New("banned from this group")
+
+// GroupBan model for GORM (one row per banned user and group)
+type GroupBan struct {
+	ID        uint       `gorm:"primaryKey"`
+	GroupID   uint       `gorm:"not null;uniqueIndex:idx_group_bans_group_user"`
+	Username  string     `gorm:"size:50;not null;uniqueIndex:idx_group_bans_group_user"`
+	BannedBy  string     `gorm:"size:50;not null"`
+	Reason    string     `gorm:"size:255"`
+	ExpiresAt *time.Time // nil = permanent
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (GroupBan) TableName() string {
+	return "group_bans"
+}
+
+// Active reports whether the ban is still in force at now
+func (b *GroupBan) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || now.Before(*b.ExpiresAt)
+}
+
+// activeBan returns the ban of username in a group if it is still in force
+func activeBan(tx *gorm.DB, groupID uint, username string) (*GroupBan, error) {
+	var ban GroupBan
+	result := tx.Where("group_id = ? AND username = ?", groupID, username).First(&ban)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	if !ban.Active(time.Now()) {
+		return nil, nil
+	}
+	return &ban, nil
+}
+
+// checkNotBanned returns ErrBannedFromGroup if username is banned from the group
+func checkNotBanned(tx *gorm.DB, groupID uint, username string) error {
+	ban, err := activeBan(tx, groupID, username)
+	if err != nil {
+		return err
+	}
+	if ban != nil {
+		return ErrBannedFromGroup
+	}
+	return nil
+}
+
+// GetActiveGroupBan returns the ban of username in a group, or nil if not banned
+func (db *DB) GetActiveGroupBan(groupID uint, username string) (*GroupBan, error) {
+	return activeBan(db.DB, groupID, username)
+}
+
+// ListGroupBans returns the bans of a group that are still in force
+func (db *DB) ListGroupBans(groupID uint) ([]GroupBan, error) {
+	var bans []GroupBan
+	result := db.Where("group_id = ? AND (expires_at IS NULL OR expires_at > ?)", groupID, time.Now()).
+		Order("created_at DESC").
+		Find(&bans)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bans, nil
+}
+
+// BanGroupMember bans username from a group, removing the membership and any
+// pending invitation or join request. ttl 0 means a permanent ban.
+func (db *DB) BanGroupMember(groupID uint, username, bannedBy, reason string, ttl time.Duration) (*GroupBan, error) {
+	ban := &GroupBan{
+		GroupID:  groupID,
+		Username: username,
+		BannedBy: bannedBy,
+		Reason:   reason,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Ban lại thì ghi đè ban cũ
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "group_id"}, {Name: "username"}},
+			DoUpdates: clause.AssignmentColumns([]string{"banned_by", "reason", "expires_at", "created_at"}),
+		}).Create(ban).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ? AND username = ?", groupID, username).Delete(&GroupMember{}).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&GroupInvitation{}, &GroupJoinRequest{}} {
+			if err := tx.Model(model).
+				Where("group_id = ? AND username = ? AND status = ?", groupID, username, RequestPending).
+				Update("status", RequestDeclined).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+// UnbanGroupMember lifts a ban
+func (db *DB) UnbanGroupMember(groupID uint, username string) error {
+	result := db.Where("group_id = ? AND username = ?", groupID, username).Delete(&GroupBan{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s is not banned", username)
+	}
+	return nil
+}
+
+// RemoveGroupMember deletes a membership
+func (db *DB) RemoveGroupMember(groupID uint, username string) error {
+	result := db.Where("group_id = ? AND username = ?", groupID, username).Delete(&GroupMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotGroupMember
+	}
+	return nil
+}
+
+// LeaveGroup removes username from a group. When the owner leaves, ownership
+// goes to the longest-standing admin, or the longest-standing member if there
+// are no admins; the new owner is returned. When the last member leaves the
+// group is deleted and empty is true.
+func (db *DB) LeaveGroup(groupID uint, username string) (newOwner string, empty bool, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		newOwner, empty, err = leaveGroup(tx, groupID, username)
+		return err
+	})
+	if err != nil || !empty {
+		return newOwner, empty, err
+	}
+	return "", true, db.DeleteGroup(groupID)
+}
+
+// leaveGroup removes a membership and hands ownership over as LeaveGroup does.
+// An empty group is reported, not deleted: the caller deletes it after commit.
+func leaveGroup(tx *gorm.DB, groupID uint, username string) (newOwner string, empty bool, err error) {
+	var member GroupMember
+	result := tx.Where("group_id = ? AND username = ?", groupID, username).First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return "", false, ErrNotGroupMember
+		}
+		return "", false, result.Error
+	}
+	if err := tx.Delete(&member).Error; err != nil {
+		return "", false, err
+	}
+	if member.Role != GroupRoleOwner {
+		return "", false, nil
+	}
+
+	var successor GroupMember
+	result = tx.Where("group_id = ?", groupID).
+		Order("CASE role WHEN 'admin' THEN 0 ELSE 1 END, joined_at ASC, id ASC").
+		First(&successor)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return "", true, nil
+	}
+	if result.Error != nil {
+		return "", false, result.Error
+	}
+	return successor.Username, false, tx.Model(&successor).Update("role", GroupRoleOwner).Error
+}
+
+// leaveGroups removes username from every group matched by where, with the
+// ownership handoff of LeaveGroup, and returns the groups left empty
+func leaveGroups(tx *gorm.DB, username, where string, args ...interface{}) ([]uint, error) {
+	var groupIDs []uint
+	if err := tx.Model(&GroupMember{}).Where("username = ?", username).Where(where, args...).
+		Pluck("group_id", &groupIDs).Error; err != nil {
+		return nil, err
+	}
+
+	var emptied []uint
+	for _, groupID := range groupIDs {
+		_, empty, err := leaveGroup(tx, groupID, username)
+		if err != nil {
+			return nil, err
+		}
+		if empty {
+			emptied = append(emptied, groupID)
+		}
+	}
+	return emptied, nil
+}
+
+// deleteGroups deletes groups left empty
+func (db *DB) deleteGroups(groupIDs []uint) error {
+	for _, groupID := range groupIDs {
+		if err := db.DeleteGroup(groupID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
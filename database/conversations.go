@@ -0,0 +1,148 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ConversationMute model for GORM: a user muted a group (GroupID) or a private chat (Peer)
+type ConversationMute struct {
+	ID         uint       `gorm:"primaryKey"`
+	Username   string     `gorm:"size:50;not null;uniqueIndex:idx_conversation_mutes_conversation"`
+	GroupID    uint       `gorm:"not null;default:0;uniqueIndex:idx_conversation_mutes_conversation"`
+	Peer       string     `gorm:"size:50;not null;default:'';uniqueIndex:idx_conversation_mutes_conversation"`
+	MutedUntil *time.Time // nil = until unmuted
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (ConversationMute) TableName() string {
+	return "conversation_mutes"
+}
+
+// Conversation is one row of a user's inbox
+type Conversation struct {
+	ChatType    string // "group" or "private"
+	GroupID     uint
+	Peer        string
+	Target      string // group name or peer username
+	LastID      *uint
+	LastFrom    string
+	LastText    string
+	LastDeleted bool
+	Activity    time.Time
+	LastReadID  uint
+	Unread      int
+	Muted       bool
+	MutedUntil  *time.Time
+}
+
+// MuteConversation mutes a conversation until a time (nil = until unmuted)
+func (db *DB) MuteConversation(username string, groupID uint, peer string, until *time.Time) error {
+	mute := &ConversationMute{Username: username, GroupID: groupID, Peer: peer, MutedUntil: until}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}, {Name: "group_id"}, {Name: "peer"}},
+		DoUpdates: clause.AssignmentColumns([]string{"muted_until", "created_at"}),
+	}).Create(mute).Error
+}
+
+// UnmuteConversation removes a mute; removed is false when the conversation was not muted
+func (db *DB) UnmuteConversation(username string, groupID uint, peer string) (bool, error) {
+	result := db.Where("username = ? AND group_id = ? AND peer = ?", username, groupID, peer).Delete(&ConversationMute{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// IsConversationMuted reports whether a mute is in force for a conversation
+func (db *DB) IsConversationMuted(username string, groupID uint, peer string) (bool, error) {
+	var count int64
+	result := db.Model(&ConversationMute{}).
+		Where("username = ? AND group_id = ? AND peer = ? AND (muted_until IS NULL OR muted_until > NOW())", username, groupID, peer).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// MutedGroupMembers returns which of usernames currently mute a group
+func (db *DB) MutedGroupMembers(groupID uint, usernames []string) (map[string]bool, error) {
+	muted := make(map[string]bool)
+	if len(usernames) == 0 {
+		return muted, nil
+	}
+	var names []string
+	result := db.Model(&ConversationMute{}).
+		Where("group_id = ? AND username IN ? AND (muted_until IS NULL OR muted_until > NOW())", groupID, usernames).
+		Pluck("username", &names)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, name := range names {
+		muted[name] = true
+	}
+	return muted, nil
+}
+
+// conversationsQuery lists the groups of a user and the peers they exchanged private
+// messages with, newest activity first. The last message comes from an index lookup
+// per group and one DISTINCT ON pass over the user's private messages; unread counts
+// are computed only for the returned page.
+const conversationsQuery = `
+SELECT page.*,
+	CASE WHEN page.chat_type = 'group' THEN (
+		SELECT COUNT(*) FROM messages m
+		WHERE m.group_id = page.group_id AND m.from_user <> @user AND m.deleted_at IS NULL
+			AND m.created_at > page.since AND m.id > page.last_read_id
+			AND (m.expires_at IS NULL OR m.expires_at > NOW())
+	) ELSE (
+		SELECT COUNT(*) FROM messages m
+		WHERE m.message_type = 'private' AND m.from_user = page.peer AND m.to_target = @user
+			AND m.deleted_at IS NULL AND m.id > page.last_read_id
+			AND (m.expires_at IS NULL OR m.expires_at > NOW())
+	) END AS unread
+FROM (
+	SELECT c.*, COALESCE(rc.last_read_id, 0) AS last_read_id,
+		mu.id IS NOT NULL AS muted, mu.muted_until
+	FROM (
+		SELECT 'group' AS chat_type, g.id AS group_id, '' AS peer, g.name AS target, gm.joined_at AS since,
+			lm.id AS last_id, lm.from_user AS last_from, lm.text AS last_text,
+			lm.deleted_at IS NOT NULL AS last_deleted, COALESCE(lm.created_at, gm.joined_at) AS activity
+		FROM group_members gm
+		JOIN groups g ON g.id = gm.group_id
+		LEFT JOIN LATERAL (
+			SELECT id, from_user, text, deleted_at, created_at FROM messages
+			WHERE group_id = gm.group_id AND (expires_at IS NULL OR expires_at > NOW())
+			ORDER BY created_at DESC, id DESC LIMIT 1
+		) lm ON TRUE
+		WHERE gm.username = @user
+		UNION ALL
+		SELECT 'private', 0, p.peer, p.peer, NULL, p.id, p.from_user, p.text,
+			p.deleted_at IS NOT NULL, p.created_at
+		FROM (
+			SELECT DISTINCT ON (peer) peer, id, from_user, text, deleted_at, created_at
+			FROM (
+				SELECT CASE WHEN from_user = @user THEN to_target ELSE from_user END AS peer,
+					id, from_user, text, deleted_at, created_at
+				FROM messages
+				WHERE message_type = 'private' AND (from_user = @user OR to_target = @user)
+					AND (expires_at IS NULL OR expires_at > NOW())
+			) pm
+			ORDER BY peer, id DESC
+		) p
+	) c
+	LEFT JOIN read_cursors rc ON rc.username = @user AND rc.group_id = c.group_id AND rc.peer = c.peer
+	LEFT JOIN conversation_mutes mu ON mu.username = @user AND mu.group_id = c.group_id AND mu.peer = c.peer
+		AND (mu.muted_until IS NULL OR mu.muted_until > NOW())
+	ORDER BY c.activity DESC, c.group_id, c.peer
+	LIMIT @limit OFFSET @offset
+) page
+ORDER BY page.activity DESC, page.group_id, page.peer`
+
+// ListConversations returns a page of the inbox of username, most recent activity first
+func (db *DB) ListConversations(username string, offset, limit int) ([]Conversation, error) {
+	var conversations []Conversation
+	result := db.Raw(conversationsQuery, map[string]interface{}{
+		"user":   username,
+		"limit":  limit,
+		"offset": offset,
+	}).Scan(&conversations)
+	return conversations, result.Error
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// PasswordCost is the bcrypt cost used for new password hashes.
+// AuthenticateUser re-hashes stored passwords created with a lower cost.
+var PasswordCost = bcrypt.DefaultCost
+
+// ErrInvalidResetToken is returned when a reset token is unknown, used or expired
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// PasswordReset model for GORM (admin-issued one-time reset tokens)
+type PasswordReset struct {
+	ID        uint      `gorm:"primaryKey"`
+	Username  string    `gorm:"size:50;not null;index"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"` // sha256 of the token, never the token itself
+	IssuedBy  string    `gorm:"size:50"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (PasswordReset) TableName() string {
+	return "password_resets"
+}
+
+// GenerateToken returns a random hex-encoded token of n bytes
+func GenerateToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken hashes a token for storage and lookup
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// needsRehash reports whether a stored hash was created with a lower cost than PasswordCost
+func needsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < PasswordCost
+}
+
+// UpdatePassword replaces the password hash of a user
+func (db *DB) UpdatePassword(username, newPassword string) error {
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	result := db.Model(&User{}).Where("username = ?", username).Update("password", hashedPassword)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreatePasswordReset issues a one-time reset token for a user.
+// Only the hash is stored; the returned token must be handed to the user.
+func (db *DB) CreatePasswordReset(username, issuedBy string, ttl time.Duration) (string, error) {
+	exists, err := db.UserExists(username)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	token, err := GenerateToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	reset := &PasswordReset{
+		Username:  username,
+		TokenHash: HashToken(token),
+		IssuedBy:  issuedBy,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := db.Create(reset).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumePasswordReset checks a reset token and sets the new password in one transaction.
+// Every other unused token of the user is invalidated as well.
+func (db *DB) ConsumePasswordReset(username, token, newPassword string) error {
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Đánh dấu token đã dùng; điều kiện WHERE đảm bảo chỉ dùng được một lần
+		now := time.Now()
+		result := tx.Model(&PasswordReset{}).
+			Where("username = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", username, HashToken(token), now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidResetToken
+		}
+
+		// Các token khác còn hạn của user cũng hết hiệu lực sau khi đặt lại mật khẩu
+		if err := tx.Model(&PasswordReset{}).
+			Where("username = ? AND used_at IS NULL", username).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&User{}).Where("username = ?", username).Update("password", hashedPassword).Error
+	})
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -13,13 +14,18 @@ import (
 
 // User model for GORM
 type User struct {
-	ID          uint      `gorm:"primaryKey"`
-	Username    string    `gorm:"uniqueIndex;size:50;not null"`
-	Password    string    `gorm:"size:255;not null"` // Hashed password
-	DisplayName string    `gorm:"size:100"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	LastSeen    time.Time `gorm:"autoUpdateTime"`
-	IsOnline    bool      `gorm:"default:false;index"`
+	ID           uint      `gorm:"primaryKey"`
+	Username     string    `gorm:"uniqueIndex;size:50;not null"`
+	Password     string    `gorm:"size:255;not null"` // Hashed password
+	DisplayName  string    `gorm:"size:100"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	LastSeen     time.Time `gorm:"autoUpdateTime"`
+	IsOnline     bool      `gorm:"default:false;index"`
+	IsBot        bool      `gorm:"default:false"`
+	BotOwner     string    `gorm:"size:50"`                         // user that created the bot
+	Role         string    `gorm:"size:20;not null;default:'user'"` // user, moderator or admin
+	DisabledAt   *time.Time
+	ReadReceipts bool `gorm:"not null;default:true"` // others see when this user read their messages
 }
 
 // TableName specifies the table name
@@ -29,9 +35,19 @@ func (User) TableName() string {
 
 // Group model for GORM
 type Group struct {
-	ID        uint      `gorm:"primaryKey"`
-	Name      string    `gorm:"uniqueIndex;size:100;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID            uint      `gorm:"primaryKey"`
+	WorkspaceID   *uint     `gorm:"uniqueIndex:idx_groups_workspace_name"`
+	Name          string    `gorm:"uniqueIndex:idx_groups_workspace_name;size:100;not null"` // handle, unique per workspace; use ID as stable key
+	DisplayName   string    `gorm:"size:100"`
+	Topic         string    `gorm:"size:255"`
+	Description   string    `gorm:"type:text"`
+	Kind          string    `gorm:"size:20;not null;default:'group'"`   // group, channel (only admins post)
+	Visibility    string    `gorm:"size:20;not null;default:'public'"`  // public, private, invite_only
+	PostPolicy    string    `gorm:"size:20;not null;default:'members'"` // who can post: members, admins
+	InvitePolicy  string    `gorm:"size:20;not null;default:'admins'"`  // who can invite: members, admins
+	MentionPolicy string    `gorm:"size:20;not null;default:'admins'"`  // who can mention @all: members, admins
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
 }
 
 // TableName specifies the table name
@@ -41,11 +57,13 @@ func (Group) TableName() string {
 
 // GroupMember model for GORM (many-to-many relationship)
 type GroupMember struct {
-	ID       uint      `gorm:"primaryKey"`
-	GroupID  uint      `gorm:"not null;index"`
-	Username string    `gorm:"size:50;not null;index"`
-	JoinedAt time.Time `gorm:"autoCreateTime"`
-	Group    Group     `gorm:"foreignKey:GroupID"`
+	ID        uint      `gorm:"primaryKey"`
+	GroupID   uint      `gorm:"not null;index"`
+	Username  string    `gorm:"size:50;not null;index"`
+	Role      string    `gorm:"size:20;not null;default:'member'"` // owner, admin or member
+	JoinedVia *uint     `gorm:"index"`                             // invite code used to join, if any
+	JoinedAt  time.Time `gorm:"autoCreateTime"`
+	Group     Group     `gorm:"foreignKey:GroupID"`
 }
 
 // TableName specifies the table name
@@ -57,10 +75,22 @@ func (GroupMember) TableName() string {
 type Message struct {
 	ID          uint      `gorm:"primaryKey"`
 	FromUser    string    `gorm:"size:50;not null;index"`
-	ToTarget    string    `gorm:"size:100;not null;index"` // username for private, group name for group
-	MessageType string    `gorm:"size:20;not null;index"`  // 'private' or 'group'
+	ToTarget    string    `gorm:"size:100;not null;index"` // username for private, group name (at send time) for group
+	GroupID     *uint     `gorm:"index"`                   // set for group and system messages
+	MessageType string    `gorm:"size:20;not null;index"`  // 'private', 'group' or 'system'
 	Text        string    `gorm:"type:text;not null"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	EditedAt    *time.Time
+	DeletedAt   *time.Time // tombstone: text is cleared, the row stays in history
+	DeletedBy   string     `gorm:"size:50"`
+	ReplyTo     *uint      `gorm:"index"`              // message this one answers
+	ThreadRoot  *uint      `gorm:"index"`              // first message of the thread; nil for top-level messages
+	ReplyCount  int        `gorm:"not null;default:0"` // on thread roots
+	LastReplyAt *time.Time
+	LastReplyBy string          `gorm:"size:50"`
+	Mentions    []MentionEntity `gorm:"serializer:json;type:text"` // parsed when sent, group messages only
+	ExpireTTL   int64           `gorm:"not null;default:0"`        // disappearing message: seconds to live, 0 = kept
+	ExpiresAt   *time.Time      `gorm:"index"`                     // set when sent, or when first read in "read" mode
 }
 
 // TableName specifies the table name
@@ -109,17 +139,52 @@ func Connect(cfg Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Foreign keys cannot point at a partitioned messages table (see PartitionMessages)
+	partitioned, err := messagesPartitioned(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect messages table: %w", err)
+	}
+	db.DisableForeignKeyConstraintWhenMigrating = partitioned
+
 	// Auto migrate the schema
-	if err := db.AutoMigrate(&User{}, &Group{}, &GroupMember{}, &Message{}); err != nil {
+	if err := db.AutoMigrate(&User{}, &Group{}, &GroupMember{}, &Message{}, &PasswordReset{}, &Session{}, &APIKey{}, &AuditLog{}, &GroupInvitation{}, &GroupJoinRequest{}, &GroupInviteCode{}, &GroupBan{}, &Workspace{}, &WorkspaceMember{}, &MessageEdit{}, &MessageReaction{}, &ReadCursor{}, &ConversationMute{}, &Attachment{}, &Mention{}, &MessagePin{}, &ScheduledMessage{}, &ConversationTTL{}, &RetentionPolicy{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Groups created before group roles existed get their first member as owner
+	if err := backfillGroupOwners(db); err != nil {
+		return nil, fmt.Errorf("failed to backfill group owners: %w", err)
+	}
+
+	// Users and groups created before workspaces existed go to the default workspace
+	if err := migrateWorkspaces(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate workspaces: %w", err)
+	}
+
+	// Group messages saved before group IDs existed are linked by name
+	if err := backfillMessageGroupIDs(db); err != nil {
+		return nil, fmt.Errorf("failed to backfill message group ids: %w", err)
+	}
+
 	// Enable pg_trgm extension for fuzzy search
 	db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm")
 
 	// Create GIN index for fuzzy search
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING gin(username gin_trgm_ops)")
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_users_display_name_trgm ON users USING gin(display_name gin_trgm_ops)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_groups_name_trgm ON groups USING gin(name gin_trgm_ops)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_groups_display_name_trgm ON groups USING gin(display_name gin_trgm_ops)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_groups_description_trgm ON groups USING gin(description gin_trgm_ops)")
+
+	// Activity ranking of the group directory counts recent messages per group
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_group_created ON messages(group_id, created_at)")
+	// Retention purges messages by type and age
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_type_created ON messages(message_type, created_at)")
+
+	// Full-text search on message text
+	if err := createSearchIndex(db); err != nil {
+		return nil, fmt.Errorf("failed to create message search index: %w", err)
+	}
 
 	log.Println("Database connected successfully")
 	return &DB{db}, nil
@@ -127,7 +192,7 @@ func Connect(cfg Config) (*DB, error) {
 
 // HashPassword hashes a password using bcrypt
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
 	return string(bytes), err
 }
 
@@ -149,6 +214,7 @@ func (db *DB) CreateUser(username, password string) (*User, error) {
 		Password:    hashedPassword,
 		DisplayName: username, // Default display name is username
 		IsOnline:    false,
+		Role:        RoleUser,
 	}
 
 	result := db.Create(user)
@@ -167,10 +233,21 @@ func (db *DB) AuthenticateUser(username, password string) (*User, error) {
 		return nil, result.Error
 	}
 
+	if user.IsBot {
+		return nil, fmt.Errorf("bot accounts authenticate with api keys")
+	}
+
 	if !CheckPassword(password, user.Password) {
 		return nil, fmt.Errorf("invalid password")
 	}
 
+	// Nâng cost bcrypt khi hash cũ yếu hơn cấu hình hiện tại
+	if needsRehash(user.Password) {
+		if err := db.UpdatePassword(username, password); err != nil {
+			log.Printf("Failed to upgrade password hash for %s: %v", username, err)
+		}
+	}
+
 	return &user, nil
 }
 
@@ -196,7 +273,8 @@ func (db *DB) UpdateUserOnlineStatus(username string, isOnline bool) error {
 
 // SearchUsers performs fuzzy search for users
 // Returns users matching the search query (case-insensitive, partial match)
-func (db *DB) SearchUsers(query string, limit int) ([]User, error) {
+// that share a workspace with viewer
+func (db *DB) SearchUsers(query, viewer string, limit int) ([]User, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -207,20 +285,25 @@ func (db *DB) SearchUsers(query string, limit int) ([]User, error) {
 	// similarity() requires pg_trgm extension
 	err := db.Raw(`
 		SELECT DISTINCT ON (username)
-			id, username, display_name, created_at, last_seen, is_online,
+			id, username, display_name, created_at, last_seen, is_online, is_bot,
 			GREATEST(
 				similarity(LOWER(username), LOWER(?)),
 				similarity(LOWER(COALESCE(display_name, '')), LOWER(?))
 			) as similarity_score
 		FROM users
-		WHERE
+		WHERE (
 			LOWER(username) LIKE LOWER(?) OR
 			LOWER(display_name) LIKE LOWER(?) OR
 			similarity(LOWER(username), LOWER(?)) > 0.3 OR
 			similarity(LOWER(COALESCE(display_name, '')), LOWER(?)) > 0.3
+		) AND username IN (
+			SELECT b.username FROM workspace_members a
+			JOIN workspace_members b ON a.workspace_id = b.workspace_id
+			WHERE a.username = ?
+		)
 		ORDER BY username, similarity_score DESC, is_online DESC
 		LIMIT ?
-	`, query, query, "%"+query+"%", "%"+query+"%", query, query, limit).Scan(&users).Error
+	`, query, query, "%"+query+"%", "%"+query+"%", query, query, viewer, limit).Scan(&users).Error
 
 	if err != nil {
 		return nil, err
@@ -271,9 +354,32 @@ func (db *DB) Close() error {
 // ========== GROUP FUNCTIONS ==========
 
 // CreateGroup creates a new group
-func (db *DB) CreateGroup(groupName string) (*Group, error) {
+func (db *DB) CreateGroup(workspaceID uint, groupName, kind, visibility string) (*Group, error) {
+	if kind == "" {
+		kind = GroupKindGroup
+	}
+	if visibility == "" {
+		visibility = GroupPublic
+	}
+	// Group public: member nào cũng mời được; còn lại chỉ admin
+	invitePolicy := GroupPolicyAdmins
+	if visibility == GroupPublic {
+		invitePolicy = GroupPolicyMembers
+	}
+	// Channel: chỉ admin được post
+	postPolicy := GroupPolicyMembers
+	if kind == GroupKindChannel {
+		postPolicy = GroupPolicyAdmins
+	}
 	group := &Group{
-		Name: groupName,
+		WorkspaceID:   &workspaceID,
+		Name:          groupName,
+		DisplayName:   groupName,
+		Kind:          kind,
+		Visibility:    visibility,
+		PostPolicy:    postPolicy,
+		InvitePolicy:  invitePolicy,
+		MentionPolicy: GroupPolicyAdmins,
 	}
 
 	result := db.Create(group)
@@ -284,20 +390,46 @@ func (db *DB) CreateGroup(groupName string) (*Group, error) {
 	return group, nil
 }
 
-// GetGroupByName gets a group by name
-func (db *DB) GetGroupByName(name string) (*Group, error) {
+// GetGroupByID gets a group by ID
+func (db *DB) GetGroupByID(id uint) (*Group, error) {
 	var group Group
-	result := db.Where("name = ?", name).First(&group)
+	result := db.First(&group, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &group, nil
 }
 
-// GroupExists checks if a group exists
-func (db *DB) GroupExists(name string) (bool, error) {
+// GetGroupByName gets a group by name among the workspaces of username. The name
+// may be qualified as "workspace/name", which still requires username to belong
+// to that workspace; a bare name found in several of them returns ErrAmbiguousGroup.
+// An empty username searches every workspace (server administration).
+func (db *DB) GetGroupByName(name, username string) (*Group, error) {
+	query := db.Where("name = ?", name)
+	if slug, groupName, ok := strings.Cut(name, "/"); ok {
+		query = db.Where("name = ? AND workspace_id = (SELECT id FROM workspaces WHERE slug = ?)", groupName, slug)
+	}
+	if username != "" {
+		query = query.Where("workspace_id IN (SELECT workspace_id FROM workspace_members WHERE username = ?)", username)
+	}
+
+	var groups []Group
+	if err := query.Limit(2).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	switch len(groups) {
+	case 0:
+		return nil, gorm.ErrRecordNotFound
+	case 1:
+		return &groups[0], nil
+	}
+	return nil, ErrAmbiguousGroup
+}
+
+// GroupExists checks if a group name is taken in a workspace
+func (db *DB) GroupExists(workspaceID uint, name string) (bool, error) {
 	var count int64
-	result := db.Model(&Group{}).Where("name = ?", name).Count(&count)
+	result := db.Model(&Group{}).Where("workspace_id = ? AND name = ?", workspaceID, name).Count(&count)
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -305,23 +437,22 @@ func (db *DB) GroupExists(name string) (bool, error) {
 }
 
 // AddGroupMember adds a user to a group
-func (db *DB) AddGroupMember(groupName, username string) error {
-	// Get group ID
-	group, err := db.GetGroupByName(groupName)
-	if err != nil {
-		return fmt.Errorf("group not found: %w", err)
+func (db *DB) AddGroupMember(groupID uint, username string) error {
+	if err := checkNotBanned(db.DB, groupID, username); err != nil {
+		return err
 	}
 
 	// Check if member already exists
 	var count int64
-	db.Model(&GroupMember{}).Where("group_id = ? AND username = ?", group.ID, username).Count(&count)
+	db.Model(&GroupMember{}).Where("group_id = ? AND username = ?", groupID, username).Count(&count)
 	if count > 0 {
 		return nil // Already a member
 	}
 
 	member := &GroupMember{
-		GroupID:  group.ID,
+		GroupID:  groupID,
 		Username: username,
+		Role:     GroupRoleMember,
 	}
 
 	return db.Create(member).Error
@@ -329,7 +460,7 @@ func (db *DB) AddGroupMember(groupName, username string) error {
 
 // GetGroupMembers gets all members of a group
 func (db *DB) GetGroupMembers(groupName string) ([]string, error) {
-	group, err := db.GetGroupByName(groupName)
+	group, err := db.GetGroupByName(groupName, "")
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +484,7 @@ func (db *DB) GetUserGroups(username string) ([]Group, error) {
 	var groups []Group
 
 	result := db.Raw(`
-		SELECT g.id, g.name, g.created_at
+		SELECT g.*
 		FROM groups g
 		INNER JOIN group_members gm ON g.id = gm.group_id
 		WHERE gm.username = ?
@@ -370,7 +501,7 @@ func (db *DB) GetUserGroups(username string) ([]Group, error) {
 // ========== MESSAGE FUNCTIONS ==========
 
 // SaveMessage saves a message to the database
-func (db *DB) SaveMessage(fromUser, toTarget, messageType, text string) error {
+func (db *DB) SaveMessage(fromUser, toTarget, messageType, text string) (*Message, error) {
 	message := &Message{
 		FromUser:    fromUser,
 		ToTarget:    toTarget,
@@ -378,10 +509,35 @@ func (db *DB) SaveMessage(fromUser, toTarget, messageType, text string) error {
 		Text:        text,
 	}
 
-	return db.Create(message).Error
+	if err := applyConversationTTL(db.DB, message); err != nil {
+		return nil, err
+	}
+	if err := db.Create(message).Error; err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// SaveGroupMessage saves a group or system message linked to the group ID
+func (db *DB) SaveGroupMessage(group *Group, fromUser, messageType, text string) (*Message, error) {
+	message := &Message{
+		FromUser:    fromUser,
+		ToTarget:    group.Name,
+		GroupID:     &group.ID,
+		MessageType: messageType,
+		Text:        text,
+	}
+
+	if err := applyConversationTTL(db.DB, message); err != nil {
+		return nil, err
+	}
+	if err := db.Create(message).Error; err != nil {
+		return nil, err
+	}
+	return message, nil
 }
 
-// GetPrivateMessages gets private messages between two users
+// GetPrivateMessages gets top-level private messages between two users (thread replies excluded)
 func (db *DB) GetPrivateMessages(user1, user2 string, limit int) ([]Message, error) {
 	if limit <= 0 {
 		limit = 100
@@ -391,7 +547,7 @@ func (db *DB) GetPrivateMessages(user1, user2 string, limit int) ([]Message, err
 	result := db.Where(
 		"(from_user = ? AND to_target = ? AND message_type = 'private') OR (from_user = ? AND to_target = ? AND message_type = 'private')",
 		user1, user2, user2, user1,
-	).Order("created_at DESC").Limit(limit).Find(&messages)
+	).Where("thread_root IS NULL").Where(notExpired).Order("created_at DESC").Limit(limit).Find(&messages)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -400,14 +556,15 @@ func (db *DB) GetPrivateMessages(user1, user2 string, limit int) ([]Message, err
 	return messages, nil
 }
 
-// GetGroupMessages gets messages for a group
-func (db *DB) GetGroupMessages(groupName string, limit int) ([]Message, error) {
+// GetGroupMessages gets top-level group and system messages of a group (thread replies excluded)
+func (db *DB) GetGroupMessages(groupID uint, limit int) ([]Message, error) {
 	if limit <= 0 {
 		limit = 100
 	}
 
 	var messages []Message
-	result := db.Where("to_target = ? AND message_type = 'group'", groupName).
+	result := db.Where("group_id = ? AND thread_root IS NULL", groupID).
+		Where(notExpired).
 		Order("created_at DESC").
 		Limit(limit).
 		Find(&messages)
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// When the timer of a disappearing message starts
+const (
+	DisappearAfterSent = "sent"
+	DisappearAfterRead = "read" // first read by someone other than the sender
+)
+
+// notExpired keeps messages whose disappearing timer has not run out. Read
+// paths use it so expired messages stay hidden until the sweeper deletes them.
+const notExpired = "(expires_at IS NULL OR expires_at > NOW())"
+
+// notExpiredIn is notExpired for the messages table under alias
+func notExpiredIn(alias string) string {
+	return "(" + alias + ".expires_at IS NULL OR " + alias + ".expires_at > NOW())"
+}
+
+// ConversationTTL model for GORM: messages sent in the conversation disappear
+// TTLSeconds after being sent or read. Keyed like pins, see PinConversation.
+type ConversationTTL struct {
+	ID           uint      `gorm:"primaryKey"`
+	Conversation string    `gorm:"size:120;uniqueIndex;not null"`
+	TTLSeconds   int64     `gorm:"not null"`
+	Mode         string    `gorm:"size:10;not null;default:'sent'"`
+	SetBy        string    `gorm:"size:50;not null"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (ConversationTTL) TableName() string {
+	return "conversation_ttls"
+}
+
+// GetConversationTTL returns the disappearing setting of a conversation, nil when off
+func (db *DB) GetConversationTTL(conversation string) (*ConversationTTL, error) {
+	var ttl ConversationTTL
+	if err := db.Where("conversation = ?", conversation).First(&ttl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ttl, nil
+}
+
+// SetConversationTTL turns disappearing messages on for a conversation, or off
+// when ttl is 0. Messages already sent keep the setting they were sent with.
+func (db *DB) SetConversationTTL(conversation string, ttl time.Duration, mode, setBy string) error {
+	if ttl <= 0 {
+		return db.Where("conversation = ?", conversation).Delete(&ConversationTTL{}).Error
+	}
+	row := &ConversationTTL{Conversation: conversation, TTLSeconds: int64(ttl / time.Second), Mode: mode, SetBy: setBy}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "conversation"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ttl_seconds", "mode", "set_by", "updated_at"}),
+	}).Create(row).Error
+}
+
+// applyConversationTTL copies the disappearing setting of the conversation onto
+// a message about to be stored
+func applyConversationTTL(tx *gorm.DB, m *Message) error {
+	var ttl ConversationTTL
+	err := tx.Where("conversation = ?", PinConversationOf(m)).First(&ttl).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m.ExpireTTL = ttl.TTLSeconds
+	if ttl.Mode == DisappearAfterSent {
+		expires := time.Now().Add(time.Duration(ttl.TTLSeconds) * time.Second)
+		m.ExpiresAt = &expires
+	}
+	return nil
+}
+
+// StartReadExpiry starts the timer of read-mode disappearing messages up to
+// messageID that reader has now read, and returns the messages whose timer
+// started. Only messages of others count, and the first reader starts it for
+// everyone.
+func (db *DB) StartReadExpiry(reader string, groupID uint, peer string, messageID uint) ([]Message, error) {
+	where, args := "group_id = ?", []interface{}{groupID}
+	if groupID == 0 {
+		where, args = "message_type = 'private' AND from_user = ? AND to_target = ?", []interface{}{peer, reader}
+	}
+
+	var started []Message
+	result := db.Raw(`
+		UPDATE messages SET expires_at = NOW() + expire_ttl * INTERVAL '1 second'
+		WHERE id <= ? AND expire_ttl > 0 AND expires_at IS NULL AND from_user <> ? AND `+where+`
+		RETURNING *
+	`, append([]interface{}{messageID, reader}, args...)...).Scan(&started)
+	return started, result.Error
+}
+
+// DeleteExpiredMessages hard-deletes up to limit messages that expired before
+// now and returns them. Rows being deleted by another server are skipped, so
+// several servers can sweep at once.
+func (db *DB) DeleteExpiredMessages(now time.Time, limit int) ([]Message, error) {
+	return db.purgeBatch("expires_at <= ?", []interface{}{now}, limit, nil)
+}
@@ -0,0 +1,321 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Roles of a member inside a group
+const (
+	GroupRoleOwner  = "owner"
+	GroupRoleAdmin  = "admin"
+	GroupRoleMember = "member"
+)
+
+var groupRoleRanks = map[string]int{
+	GroupRoleMember: 0,
+	GroupRoleAdmin:  1,
+	GroupRoleOwner:  2,
+}
+
+// GroupRoleAtLeast reports whether a group role has at least the privileges of min.
+// An empty role (not a member) never qualifies.
+func GroupRoleAtLeast(role, min string) bool {
+	rank, ok := groupRoleRanks[role]
+	return ok && rank >= groupRoleRanks[min]
+}
+
+// Visibility of a group
+const (
+	GroupPublic     = "public"      // anyone can join and read
+	GroupPrivate    = "private"     // join requests need admin approval
+	GroupInviteOnly = "invite_only" // members join only by invitation
+)
+
+// Kinds of group
+const (
+	GroupKindGroup   = "group"   // everyone allowed by the post policy can post
+	GroupKindChannel = "channel" // announcement channel, only admins post
+)
+
+// ValidGroupKind reports whether k is a known group kind
+func ValidGroupKind(k string) bool {
+	return k == GroupKindGroup || k == GroupKindChannel
+}
+
+// Who may post in, invite to or mention everyone of a group
+const (
+	GroupPolicyMembers = "members"
+	GroupPolicyAdmins  = "admins"
+)
+
+// ValidGroupPolicy reports whether p is a known post, invite or mention policy
+func ValidGroupPolicy(p string) bool {
+	return p == GroupPolicyMembers || p == GroupPolicyAdmins
+}
+
+// PolicyRole returns the minimum group role a policy requires
+func PolicyRole(policy string) string {
+	if policy == GroupPolicyAdmins {
+		return GroupRoleAdmin
+	}
+	return GroupRoleMember
+}
+
+// ValidGroupVisibility reports whether v is a known group visibility
+func ValidGroupVisibility(v string) bool {
+	return v == GroupPublic || v == GroupPrivate || v == GroupInviteOnly
+}
+
+// ErrNotGroupMember is returned when a user is not a member of a group
+var ErrNotGroupMember = errors.New("not a member of this group")
+
+// backfillGroupOwners makes the earliest member the owner of groups created
+// before group roles existed
+func backfillGroupOwners(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE group_members gm SET role = 'owner'
+		FROM (
+			SELECT DISTINCT ON (group_id) id
+			FROM group_members
+			WHERE group_id NOT IN (SELECT group_id FROM group_members WHERE role = 'owner')
+			ORDER BY group_id, joined_at ASC, id ASC
+		) first_member
+		WHERE gm.id = first_member.id
+	`).Error
+}
+
+// backfillMessageGroupIDs links group messages saved before messages.group_id
+// existed to their group by name
+func backfillMessageGroupIDs(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE messages m SET group_id = g.id
+		FROM groups g
+		WHERE m.message_type = 'group' AND m.group_id IS NULL AND m.to_target = g.name
+	`).Error
+}
+
+// AddGroupMemberWithRole adds a user to a group with a role, or updates the
+// role if the user is already a member
+func (db *DB) AddGroupMemberWithRole(groupID uint, username, role string) error {
+	var member GroupMember
+	result := db.Where("group_id = ? AND username = ?", groupID, username).First(&member)
+	if result.Error == nil {
+		return db.Model(&member).Update("role", role).Error
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	return db.Create(&GroupMember{
+		GroupID:  groupID,
+		Username: username,
+		Role:     role,
+	}).Error
+}
+
+// GetGroupMemberRole returns the role of a user in a group, or ErrNotGroupMember
+func (db *DB) GetGroupMemberRole(groupID uint, username string) (string, error) {
+	var member GroupMember
+	result := db.Where("group_id = ? AND username = ?", groupID, username).First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return "", ErrNotGroupMember
+		}
+		return "", result.Error
+	}
+	return member.Role, nil
+}
+
+// GetGroupMemberDetails returns the member rows of a group ordered by join time
+func (db *DB) GetGroupMemberDetails(groupID uint) ([]GroupMember, error) {
+	var members []GroupMember
+	result := db.Where("group_id = ?", groupID).Order("joined_at ASC, id ASC").Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// SetGroupMemberRole changes the role of an existing member
+func (db *DB) SetGroupMemberRole(groupID uint, username, role string) error {
+	result := db.Model(&GroupMember{}).
+		Where("group_id = ? AND username = ?", groupID, username).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotGroupMember
+	}
+	return nil
+}
+
+// TransferGroupOwnership makes newOwner the owner and demotes the old owner to admin
+func (db *DB) TransferGroupOwnership(groupID uint, oldOwner, newOwner string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&GroupMember{}).
+			Where("group_id = ? AND username = ?", groupID, newOwner).
+			Update("role", GroupRoleOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotGroupMember
+		}
+
+		return tx.Model(&GroupMember{}).
+			Where("group_id = ? AND username = ?", groupID, oldOwner).
+			Update("role", GroupRoleAdmin).Error
+	})
+}
+
+// IsGroupMember reports whether username is a member of the group
+func (db *DB) IsGroupMember(groupID uint, username string) (bool, error) {
+	var count int64
+	result := db.Model(&GroupMember{}).Where("group_id = ? AND username = ?", groupID, username).Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// SetGroupVisibility changes the visibility of a group
+func (db *DB) SetGroupVisibility(groupID uint, visibility string) error {
+	if !ValidGroupVisibility(visibility) {
+		return errors.New("unknown visibility")
+	}
+	return db.Model(&Group{}).Where("id = ?", groupID).Update("visibility", visibility).Error
+}
+
+// GetGroupAdmins returns the usernames of the owner and admins of a group
+func (db *DB) GetGroupAdmins(groupID uint) ([]string, error) {
+	var usernames []string
+	result := db.Model(&GroupMember{}).
+		Where("group_id = ? AND role IN ?", groupID, []string{GroupRoleOwner, GroupRoleAdmin}).
+		Pluck("username", &usernames)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return usernames, nil
+}
+
+// CountGroupMembers returns the number of members of a group
+func (db *DB) CountGroupMembers(groupID uint) (int64, error) {
+	var count int64
+	result := db.Model(&GroupMember{}).Where("group_id = ?", groupID).Count(&count)
+	return count, result.Error
+}
+
+// FilterGroupMembers returns which of usernames are members of the group.
+// Used for fan-out so only the online users are looked up, not the whole audience.
+func (db *DB) FilterGroupMembers(groupID uint, usernames []string) ([]string, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	var members []string
+	result := db.Model(&GroupMember{}).
+		Where("group_id = ? AND username IN ?", groupID, usernames).
+		Pluck("username", &members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// UpdateGroup applies metadata changes to a group. A renamed group keeps its ID,
+// so members and history stay attached.
+func (db *DB) UpdateGroup(groupID uint, changes map[string]interface{}) (*Group, error) {
+	if len(changes) > 0 {
+		if err := db.Model(&Group{ID: groupID}).Updates(changes).Error; err != nil {
+			return nil, err
+		}
+	}
+	return db.GetGroupByID(groupID)
+}
+
+// GroupSummary is a directory entry: a group with its member count and activity
+type GroupSummary struct {
+	Group          `gorm:"embedded"`
+	WorkspaceSlug  string
+	MemberCount    int64
+	RecentMessages int64 // messages in the activity window
+	LastActivity   *time.Time
+	Score          float64 // search similarity, 0 when listing
+}
+
+// groupActivityWindow is how far back messages count towards activity ranking
+const groupActivityWindow = 7 * 24 * time.Hour
+
+// groupSummarySelect computes member count and activity for each group row g
+const groupSummarySelect = `
+	g.*,
+	(SELECT w.slug FROM workspaces w WHERE w.id = g.workspace_id) AS workspace_slug,
+	(SELECT COUNT(*) FROM group_members gm WHERE gm.group_id = g.id) AS member_count,
+	(SELECT COUNT(*) FROM messages m WHERE m.group_id = g.id AND m.created_at > ?) AS recent_messages,
+	(SELECT MAX(m.created_at) FROM messages m WHERE m.group_id = g.id) AS last_activity`
+
+// ListPublicGroups lists public groups of the given workspaces, most active first
+func (db *DB) ListPublicGroups(workspaceIDs []uint, offset, limit int) ([]GroupSummary, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var groups []GroupSummary
+	result := db.Raw(`
+		SELECT `+groupSummarySelect+`
+		FROM groups g
+		WHERE g.visibility = ? AND g.workspace_id IN ?
+		ORDER BY recent_messages DESC, member_count DESC, last_activity DESC NULLS LAST, g.name ASC
+		OFFSET ? LIMIT ?
+	`, time.Now().Add(-groupActivityWindow), GroupPublic, workspaceIDs, offset, limit).Scan(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return groups, nil
+}
+
+// SearchGroups performs fuzzy search over the names, display names and
+// descriptions of public groups in the given workspaces. Results are ranked by
+// similarity, then activity.
+func (db *DB) SearchGroups(query string, workspaceIDs []uint, offset, limit int) ([]GroupSummary, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// similarity() / word_similarity() require pg_trgm extension
+	var groups []GroupSummary
+	like := "%" + query + "%"
+	result := db.Raw(`
+		SELECT * FROM (
+			SELECT `+groupSummarySelect+`,
+				GREATEST(
+					similarity(LOWER(g.name), LOWER(?)),
+					similarity(LOWER(COALESCE(g.display_name, '')), LOWER(?)),
+					word_similarity(LOWER(?), LOWER(COALESCE(g.description, '')))
+				) AS score
+			FROM groups g
+			WHERE g.visibility = ? AND g.workspace_id IN ? AND (
+				LOWER(g.name) LIKE LOWER(?) OR
+				LOWER(g.display_name) LIKE LOWER(?) OR
+				LOWER(g.description) LIKE LOWER(?) OR
+				similarity(LOWER(g.name), LOWER(?)) > 0.3 OR
+				similarity(LOWER(COALESCE(g.display_name, '')), LOWER(?)) > 0.3 OR
+				word_similarity(LOWER(?), LOWER(COALESCE(g.description, ''))) > 0.3
+			)
+		) ranked
+		ORDER BY score DESC, recent_messages DESC, member_count DESC, name ASC
+		OFFSET ? LIMIT ?
+	`, time.Now().Add(-groupActivityWindow),
+		query, query, query,
+		GroupPublic, workspaceIDs,
+		like, like, like,
+		query, query, query,
+		offset, limit).Scan(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return groups, nil
+}
@@ -0,0 +1,223 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status of invitations and join requests
+const (
+	RequestPending  = "pending"
+	RequestAccepted = "accepted"
+	RequestDeclined = "declined"
+)
+
+var (
+	// ErrRequestNotFound is returned when an invitation or join request is unknown or already handled
+	ErrRequestNotFound = errors.New("request not found or already handled")
+	// ErrRequestExists is returned when a pending invitation or join request already exists
+	ErrRequestExists = errors.New("a pending request already exists")
+)
+
+// GroupInvitation model for GORM (an admin invites a user into a group)
+type GroupInvitation struct {
+	ID          uint      `gorm:"primaryKey"`
+	GroupID     uint      `gorm:"not null;index"`
+	Group       Group     `gorm:"foreignKey:GroupID"`
+	Username    string    `gorm:"size:50;not null;index"` // invitee
+	InvitedBy   string    `gorm:"size:50;not null"`
+	Status      string    `gorm:"size:20;not null;default:'pending'"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	RespondedAt *time.Time
+}
+
+// TableName specifies the table name
+func (GroupInvitation) TableName() string {
+	return "group_invitations"
+}
+
+// GroupJoinRequest model for GORM (a user asks to join a private group)
+type GroupJoinRequest struct {
+	ID         uint      `gorm:"primaryKey"`
+	GroupID    uint      `gorm:"not null;index"`
+	Group      Group     `gorm:"foreignKey:GroupID"`
+	Username   string    `gorm:"size:50;not null;index"`
+	Status     string    `gorm:"size:20;not null;default:'pending'"`
+	ReviewedBy string    `gorm:"size:50"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	ReviewedAt *time.Time
+}
+
+// TableName specifies the table name
+func (GroupJoinRequest) TableName() string {
+	return "group_join_requests"
+}
+
+// CreateGroupInvitation records a pending invitation
+func (db *DB) CreateGroupInvitation(groupID uint, username, invitedBy string) (*GroupInvitation, error) {
+	var count int64
+	db.Model(&GroupInvitation{}).
+		Where("group_id = ? AND username = ? AND status = ?", groupID, username, RequestPending).
+		Count(&count)
+	if count > 0 {
+		return nil, ErrRequestExists
+	}
+
+	inv := &GroupInvitation{
+		GroupID:   groupID,
+		Username:  username,
+		InvitedBy: invitedBy,
+		Status:    RequestPending,
+	}
+	if err := db.Create(inv).Error; err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ListPendingInvitations returns the pending invitations of a user with their groups
+func (db *DB) ListPendingInvitations(username string) ([]GroupInvitation, error) {
+	var invs []GroupInvitation
+	result := db.Preload("Group").
+		Where("username = ? AND status = ?", username, RequestPending).
+		Order("created_at DESC").
+		Find(&invs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return invs, nil
+}
+
+// RespondGroupInvitation accepts or declines a pending invitation of username.
+// Accepting adds the user to the group and closes any pending join request.
+func (db *DB) RespondGroupInvitation(id uint, username string, accept bool) (*GroupInvitation, error) {
+	var inv GroupInvitation
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("Group").
+			Where("id = ? AND username = ? AND status = ?", id, username, RequestPending).
+			First(&inv)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrRequestNotFound
+			}
+			return result.Error
+		}
+
+		now := time.Now()
+		inv.Status = RequestDeclined
+		if accept {
+			inv.Status = RequestAccepted
+		}
+		inv.RespondedAt = &now
+		if err := tx.Save(&inv).Error; err != nil {
+			return err
+		}
+		if !accept {
+			return nil
+		}
+
+		if err := addMemberTx(tx, inv.GroupID, username); err != nil {
+			return err
+		}
+		return tx.Model(&GroupJoinRequest{}).
+			Where("group_id = ? AND username = ? AND status = ?", inv.GroupID, username, RequestPending).
+			Updates(map[string]interface{}{"status": RequestAccepted, "reviewed_by": inv.InvitedBy, "reviewed_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// CreateJoinRequest records a pending request of username to join a group
+func (db *DB) CreateJoinRequest(groupID uint, username string) (*GroupJoinRequest, error) {
+	var count int64
+	db.Model(&GroupJoinRequest{}).
+		Where("group_id = ? AND username = ? AND status = ?", groupID, username, RequestPending).
+		Count(&count)
+	if count > 0 {
+		return nil, ErrRequestExists
+	}
+
+	req := &GroupJoinRequest{
+		GroupID:  groupID,
+		Username: username,
+		Status:   RequestPending,
+	}
+	if err := db.Create(req).Error; err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListPendingJoinRequests returns the pending join requests of a group
+func (db *DB) ListPendingJoinRequests(groupID uint) ([]GroupJoinRequest, error) {
+	var reqs []GroupJoinRequest
+	result := db.Where("group_id = ? AND status = ?", groupID, RequestPending).
+		Order("created_at ASC").
+		Find(&reqs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reqs, nil
+}
+
+// GetPendingJoinRequest gets a pending join request by ID with its group
+func (db *DB) GetPendingJoinRequest(id uint) (*GroupJoinRequest, error) {
+	var req GroupJoinRequest
+	result := db.Preload("Group").Where("id = ? AND status = ?", id, RequestPending).First(&req)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, result.Error
+	}
+	return &req, nil
+}
+
+// ReviewJoinRequest approves or rejects a pending join request.
+// Approving adds the requester to the group.
+func (db *DB) ReviewJoinRequest(id uint, reviewer string, approve bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var req GroupJoinRequest
+		result := tx.Where("id = ? AND status = ?", id, RequestPending).First(&req)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrRequestNotFound
+			}
+			return result.Error
+		}
+
+		now := time.Now()
+		req.Status = RequestDeclined
+		if approve {
+			req.Status = RequestAccepted
+		}
+		req.ReviewedBy = reviewer
+		req.ReviewedAt = &now
+		if err := tx.Save(&req).Error; err != nil {
+			return err
+		}
+		if !approve {
+			return nil
+		}
+		return addMemberTx(tx, req.GroupID, req.Username)
+	})
+}
+
+// addMemberTx adds a plain member inside a transaction, ignoring existing members.
+// Banned users are refused with ErrBannedFromGroup.
+func addMemberTx(tx *gorm.DB, groupID uint, username string) error {
+	if err := checkNotBanned(tx, groupID, username); err != nil {
+		return err
+	}
+
+	var count int64
+	tx.Model(&GroupMember{}).Where("group_id = ? AND username = ?", groupID, username).Count(&count)
+	if count > 0 {
+		return nil
+	}
+	return tx.Create(&GroupMember{GroupID: groupID, Username: username, Role: GroupRoleMember}).Error
+}
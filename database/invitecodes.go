@@ -0,0 +1,179 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidInviteCode is returned when an invite code is unknown, revoked, expired or used up
+	ErrInvalidInviteCode = errors.New("invite code is invalid, expired or used up")
+	// ErrAlreadyMember is returned when a user redeems an invite for a group they are in
+	ErrAlreadyMember = errors.New("already a member of this group")
+)
+
+// GroupInviteCode model for GORM (shareable invite links created by group admins)
+type GroupInviteCode struct {
+	ID        uint       `gorm:"primaryKey"`
+	GroupID   uint       `gorm:"not null;index"`
+	Group     Group      `gorm:"foreignKey:GroupID"`
+	Code      string     `gorm:"size:32;uniqueIndex;not null"`
+	CreatedBy string     `gorm:"size:50;not null"`
+	ExpiresAt *time.Time // nil = never expires
+	MaxUses   int        `gorm:"not null;default:0"` // 0 = unlimited
+	Uses      int        `gorm:"not null;default:0"`
+	RevokedAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (GroupInviteCode) TableName() string {
+	return "group_invite_codes"
+}
+
+// Usable reports whether the code can still be redeemed at now
+func (c *GroupInviteCode) Usable(now time.Time) bool {
+	if c.RevokedAt != nil {
+		return false
+	}
+	if c.ExpiresAt != nil && !now.Before(*c.ExpiresAt) {
+		return false
+	}
+	return c.MaxUses == 0 || c.Uses < c.MaxUses
+}
+
+// CreateInviteCode creates an invite code for a group. ttl 0 means no expiry,
+// maxUses 0 means unlimited uses.
+func (db *DB) CreateInviteCode(groupID uint, createdBy string, ttl time.Duration, maxUses int) (*GroupInviteCode, error) {
+	code, err := GenerateToken(5)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &GroupInviteCode{
+		GroupID:   groupID,
+		Code:      code,
+		CreatedBy: createdBy,
+		MaxUses:   maxUses,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		invite.ExpiresAt = &expiresAt
+	}
+	if err := db.Create(invite).Error; err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// GetInviteCode gets an invite code with its group
+func (db *DB) GetInviteCode(code string) (*GroupInviteCode, error) {
+	var invite GroupInviteCode
+	result := db.Preload("Group").Where("code = ?", code).First(&invite)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidInviteCode
+		}
+		return nil, result.Error
+	}
+	return &invite, nil
+}
+
+// ListInviteCodes returns the invite codes of a group, newest first
+func (db *DB) ListInviteCodes(groupID uint) ([]GroupInviteCode, error) {
+	var invites []GroupInviteCode
+	result := db.Where("group_id = ?", groupID).Order("created_at DESC").Find(&invites)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return invites, nil
+}
+
+// GetInviteCodeMembers returns the usernames that joined through each invite code
+func (db *DB) GetInviteCodeMembers(groupID uint) (map[uint][]string, error) {
+	var members []GroupMember
+	result := db.Where("group_id = ? AND joined_via IS NOT NULL", groupID).Order("joined_at ASC").Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	byCode := make(map[uint][]string)
+	for _, m := range members {
+		byCode[*m.JoinedVia] = append(byCode[*m.JoinedVia], m.Username)
+	}
+	return byCode, nil
+}
+
+// RevokeInviteCode revokes an invite code
+func (db *DB) RevokeInviteCode(id uint) error {
+	result := db.Model(&GroupInviteCode{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidInviteCode
+	}
+	return nil
+}
+
+// RedeemInviteCode adds username to the group of a usable code and counts the use.
+// The code row is locked so concurrent redeems cannot exceed MaxUses; a user
+// outside the workspace of the group gets ErrNotWorkspaceMember.
+func (db *DB) RedeemInviteCode(code, username string) (*GroupInviteCode, error) {
+	var invite GroupInviteCode
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&invite)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrInvalidInviteCode
+			}
+			return result.Error
+		}
+		if !invite.Usable(time.Now()) {
+			return ErrInvalidInviteCode
+		}
+		if err := checkNotBanned(tx, invite.GroupID, username); err != nil {
+			return err
+		}
+
+		// Codes only work for users in the workspace of the group
+		var inWorkspace int64
+		if err := tx.Model(&WorkspaceMember{}).
+			Where("username = ? AND workspace_id = (SELECT workspace_id FROM groups WHERE id = ?)", username, invite.GroupID).
+			Count(&inWorkspace).Error; err != nil {
+			return err
+		}
+		if inWorkspace == 0 {
+			return ErrNotWorkspaceMember
+		}
+
+		var count int64
+		if err := tx.Model(&GroupMember{}).Where("group_id = ? AND username = ?", invite.GroupID, username).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyMember
+		}
+
+		if err := tx.Create(&GroupMember{
+			GroupID:   invite.GroupID,
+			Username:  username,
+			Role:      GroupRoleMember,
+			JoinedVia: &invite.ID,
+		}).Error; err != nil {
+			return err
+		}
+		invite.Uses++
+		return tx.Model(&invite).Update("uses", invite.Uses).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.First(&invite.Group, invite.GroupID).Error; err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
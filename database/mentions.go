@@ -0,0 +1,134 @@
+package database
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kinds of mention
+const (
+	MentionUser = "user" // @username
+	MentionHere = "here" // online members
+	MentionAll  = "all"  // every member
+)
+
+// MentionEntity is a mention in the text of a message
+type MentionEntity struct {
+	Kind     string `json:"kind"`
+	Username string `json:"username,omitempty"` // for user mentions
+	Offset   int    `json:"offset"`             // byte offset of "@" in the text
+	Length   int    `json:"length"`             // bytes, including "@"
+}
+
+// Mention model for GORM (a user mentioned by a group message, for the mentions inbox)
+type Mention struct {
+	ID        uint      `gorm:"primaryKey"`
+	MessageID uint      `gorm:"not null;uniqueIndex:idx_mentions_message_user;index:idx_mentions_username_message,priority:2"`
+	Message   Message   `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE"`
+	Username  string    `gorm:"size:50;not null;uniqueIndex:idx_mentions_message_user;index:idx_mentions_username_message,priority:1"`
+	GroupID   uint      `gorm:"not null;index"`
+	Kind      string    `gorm:"size:10;not null"` // user, here or all
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (Mention) TableName() string {
+	return "mentions"
+}
+
+// MentionedMessage is an entry of the mentions inbox
+type MentionedMessage struct {
+	Message     `gorm:"embedded"`
+	MentionKind string
+	Unread      bool
+}
+
+// SaveMentions stores the mention entities of a group message and one row per
+// mentioned user. Direct mentions win over @here, which wins over @all; the
+// sender is never mentioned.
+func (db *DB) SaveMentions(message *Message, entities []MentionEntity, users, here []string, all bool) error {
+	if len(entities) == 0 || message.GroupID == nil {
+		return nil
+	}
+	groupID := *message.GroupID
+	encoded, err := json.Marshal(entities)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(message).Update("mentions", string(encoded)).Error; err != nil {
+			return err
+		}
+		message.Mentions = entities
+
+		// Direct mentions first so they win on conflict
+		for _, batch := range []struct {
+			kind  string
+			names []string
+		}{{MentionUser, users}, {MentionHere, here}} {
+			var rows []Mention
+			for _, name := range batch.names {
+				if name != message.FromUser {
+					rows = append(rows, Mention{MessageID: message.ID, Username: name, GroupID: groupID, Kind: batch.kind})
+				}
+			}
+			if len(rows) == 0 {
+				continue
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+
+		if !all {
+			return nil
+		}
+		return tx.Exec(`
+			INSERT INTO mentions (message_id, username, group_id, kind, created_at)
+			SELECT ?, username, group_id, ?, NOW() FROM group_members
+			WHERE group_id = ? AND username <> ?
+			ON CONFLICT DO NOTHING
+		`, message.ID, MentionAll, groupID, message.FromUser).Error
+	})
+}
+
+// SetMessageMentions replaces the mention entities of a message, e.g. after an
+// edit. The mentions inbox is left as it was.
+func (db *DB) SetMessageMentions(message *Message, entities []MentionEntity) error {
+	encoded, err := json.Marshal(entities)
+	if err != nil {
+		return err
+	}
+	if err := db.Model(message).Update("mentions", string(encoded)).Error; err != nil {
+		return err
+	}
+	message.Mentions = entities
+	return nil
+}
+
+// ListMentions returns the live messages mentioning username in groups they
+// still belong to, newest first. beforeID > 0 pages backwards from that message.
+// A mention is unread when its message is newer than the user's read cursor of the group.
+func (db *DB) ListMentions(username string, beforeID uint, unreadOnly bool, limit int) ([]MentionedMessage, error) {
+	query := db.Table("mentions mn").
+		Select("m.*, mn.kind AS mention_kind, m.id > COALESCE(rc.last_read_id, 0) AS unread").
+		Joins("JOIN messages m ON m.id = mn.message_id").
+		Joins("JOIN group_members gm ON gm.group_id = mn.group_id AND gm.username = mn.username").
+		Joins("LEFT JOIN read_cursors rc ON rc.username = mn.username AND rc.group_id = mn.group_id AND rc.peer = ''").
+		Where("mn.username = ? AND m.deleted_at IS NULL", username).
+		Where(notExpiredIn("m"))
+	if beforeID > 0 {
+		query = query.Where("mn.message_id < ?", beforeID)
+	}
+	if unreadOnly {
+		query = query.Where("m.id > COALESCE(rc.last_read_id, 0)")
+	}
+
+	var mentions []MentionedMessage
+	result := query.Order("mn.message_id DESC").Limit(limit).Scan(&mentions)
+	return mentions, result.Error
+}
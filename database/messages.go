@@ -0,0 +1,131 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrMessageNotFound is returned when a message ID does not exist
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrMessageDeleted is returned when editing or deleting a tombstone
+	ErrMessageDeleted = errors.New("message was deleted")
+)
+
+// MessageEdit model for GORM: the text a message had before one edit
+type MessageEdit struct {
+	ID        uint      `gorm:"primaryKey"`
+	MessageID uint      `gorm:"not null;index"`
+	OldText   string    `gorm:"type:text;not null"`
+	EditedBy  string    `gorm:"size:50;not null"`
+	EditedAt  time.Time `gorm:"autoCreateTime"`
+	Message   Message   `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name
+func (MessageEdit) TableName() string {
+	return "message_edits"
+}
+
+// Deleted reports whether the message is a tombstone
+func (m *Message) Deleted() bool {
+	return m.DeletedAt != nil
+}
+
+// GetMessage gets a message by ID, tombstones included
+func (db *DB) GetMessage(id uint) (*Message, error) {
+	var message Message
+	if err := db.First(&message, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+// lockLiveMessage loads a message for update and rejects tombstones
+func lockLiveMessage(tx *gorm.DB, id uint) (*Message, error) {
+	var message Message
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&message, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	if message.Deleted() {
+		return nil, ErrMessageDeleted
+	}
+	return &message, nil
+}
+
+// EditMessage replaces the text of a message and records the previous text
+func (db *DB) EditMessage(id uint, editor, text string) (*Message, error) {
+	var message *Message
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		message, err = lockLiveMessage(tx, id)
+		if err != nil {
+			return err
+		}
+
+		edit := &MessageEdit{MessageID: id, OldText: message.Text, EditedBy: editor}
+		if err := tx.Create(edit).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		message.Text = text
+		message.EditedAt = &now
+		return tx.Model(message).Updates(map[string]interface{}{"text": text, "edited_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// DeleteMessage turns a message into a tombstone. The text, its mentions, edit
+// history, reactions and pin are dropped and its attachments detached; the row stays so
+// history keeps its place.
+func (db *DB) DeleteMessage(id uint, deletedBy string) (*Message, error) {
+	var message *Message
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		message, err = lockLiveMessage(tx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&MessageEdit{}, &MessageReaction{}, &MessagePin{}} {
+			if err := tx.Where("message_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		// Detached files are removed with their blobs by the attachment cleanup
+		if err := tx.Model(&Attachment{}).Where("message_id = ?", id).Update("message_id", nil).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		message.Text = ""
+		message.DeletedAt = &now
+		message.DeletedBy = deletedBy
+		message.Mentions = nil
+		return tx.Model(message).Updates(map[string]interface{}{"text": "", "deleted_at": now, "deleted_by": deletedBy, "mentions": nil}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// GetMessageEdits returns the edit history of a message, oldest first
+func (db *DB) GetMessageEdits(id uint) ([]MessageEdit, error) {
+	var edits []MessageEdit
+	result := db.Where("message_id = ?", id).Order("edited_at ASC").Find(&edits)
+	return edits, result.Error
+}
@@ -0,0 +1,208 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Monthly partitions of messages are named after their month, e.g. messages_2026_10
+const (
+	partitionNameLayout = "messages_2006_01"
+	defaultPartition    = "messages_default" // rows outside every month partition
+)
+
+// ErrAlreadyPartitioned is returned when messages is already partitioned
+var ErrAlreadyPartitioned = errors.New("messages is already partitioned")
+
+// messagesPartitioned reports whether messages is a partitioned table
+func messagesPartitioned(db *gorm.DB) (bool, error) {
+	var partitioned bool
+	err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass('messages'))").Scan(&partitioned).Error
+	return partitioned, err
+}
+
+// MessagesPartitioned reports whether messages is partitioned by month
+func (db *DB) MessagesPartitioned() (bool, error) {
+	return messagesPartitioned(db.DB)
+}
+
+// monthStart returns the first instant of the month of t, in UTC
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// createMonthPartition creates the partition of messages for the month starting at month
+func createMonthPartition(tx *gorm.DB, month time.Time) error {
+	return tx.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF messages FOR VALUES FROM ('%s') TO ('%s')",
+		month.Format(partitionNameLayout), month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339),
+	)).Error
+}
+
+// PartitionMessages turns messages into a table partitioned by month of
+// created_at, with partitions from the oldest message to ahead months past now
+// and a default partition for anything else. Run it while no server is
+// running: the table is locked and copied in one transaction.
+//
+// A partitioned table can only have unique keys that include created_at, so
+// the primary key becomes (id, created_at) and foreign keys to messages are
+// dropped; rows referencing messages are deleted with them by the code instead.
+func (db *DB) PartitionMessages(now time.Time, ahead int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		partitioned, err := messagesPartitioned(tx)
+		if err != nil {
+			return err
+		}
+		if partitioned {
+			return ErrAlreadyPartitioned
+		}
+
+		if err := tx.Exec("LOCK TABLE messages IN ACCESS EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE messages SET created_at = NOW() WHERE created_at IS NULL").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("ALTER TABLE messages RENAME TO messages_unpartitioned").Error; err != nil {
+			return err
+		}
+
+		// Indexes are recreated on the new table once the old one is gone; unique
+		// ones (the old primary key) cannot be kept without created_at
+		var indexDefs []string
+		if err := tx.Raw(`
+			SELECT indexdef FROM pg_indexes
+			WHERE schemaname = current_schema() AND tablename = 'messages_unpartitioned' AND indexdef NOT LIKE 'CREATE UNIQUE%'
+		`).Scan(&indexDefs).Error; err != nil {
+			return err
+		}
+		var columns []string
+		if err := tx.Raw(`
+			SELECT quote_ident(column_name) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'messages_unpartitioned' AND is_generated = 'NEVER'
+			ORDER BY ordinal_position
+		`).Scan(&columns).Error; err != nil {
+			return err
+		}
+		var sequence string
+		if err := tx.Raw("SELECT COALESCE(pg_get_serial_sequence('messages_unpartitioned', 'id'), '')").Scan(&sequence).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			CREATE TABLE messages (
+				LIKE messages_unpartitioned INCLUDING DEFAULTS INCLUDING GENERATED,
+				PRIMARY KEY (id, created_at)
+			) PARTITION BY RANGE (created_at)
+		`).Error; err != nil {
+			return err
+		}
+		// The ID sequence moves to the new table so dropping the old one keeps it
+		if sequence != "" {
+			if err := tx.Exec("ALTER SEQUENCE " + sequence + " OWNED BY messages.id").Error; err != nil {
+				return err
+			}
+		}
+
+		var oldest *time.Time
+		if err := tx.Raw("SELECT MIN(created_at) FROM messages_unpartitioned").Scan(&oldest).Error; err != nil {
+			return err
+		}
+		from := monthStart(now)
+		if oldest != nil && oldest.Before(from) {
+			from = monthStart(*oldest)
+		}
+		for month := from; !month.After(monthStart(now).AddDate(0, ahead, 0)); month = month.AddDate(0, 1, 0) {
+			if err := createMonthPartition(tx, month); err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("CREATE TABLE " + defaultPartition + " PARTITION OF messages DEFAULT").Error; err != nil {
+			return err
+		}
+
+		list := strings.Join(columns, ", ")
+		if err := tx.Exec("INSERT INTO messages (" + list + ") SELECT " + list + " FROM messages_unpartitioned").Error; err != nil {
+			return err
+		}
+		// CASCADE drops the foreign keys of other tables that point at the old table
+		if err := tx.Exec("DROP TABLE messages_unpartitioned CASCADE").Error; err != nil {
+			return err
+		}
+		for _, def := range indexDefs {
+			if err := tx.Exec(strings.Replace(def, "messages_unpartitioned ", "messages ", 1)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// messagePartitions returns the month partitions of messages with their month
+func (db *DB) messagePartitions() (map[string]time.Time, error) {
+	var names []string
+	if err := db.Raw(`
+		SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass('messages')
+	`).Scan(&names).Error; err != nil {
+		return nil, err
+	}
+
+	partitions := make(map[string]time.Time)
+	for _, name := range names {
+		if month, err := time.Parse(partitionNameLayout, name); err == nil {
+			partitions[name] = month
+		}
+	}
+	return partitions, nil
+}
+
+// MaintainMessagePartitions creates the partitions of the next ahead months and
+// drops month partitions that retention has emptied, except the current and the
+// previous month. It returns the names of the dropped partitions.
+func (db *DB) MaintainMessagePartitions(now time.Time, ahead int) ([]string, error) {
+	for i := 0; i <= ahead; i++ {
+		if err := createMonthPartition(db.DB, monthStart(now).AddDate(0, i, 0)); err != nil {
+			return nil, err
+		}
+	}
+
+	partitions, err := db.messagePartitions()
+	if err != nil {
+		return nil, err
+	}
+	keepFrom := monthStart(now).AddDate(0, -1, 0)
+	var dropped []string
+	for name, month := range partitions {
+		if !month.Before(keepFrom) {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// The lock keeps rows from arriving between the check and the drop
+			if err := tx.Exec("LOCK TABLE " + name + " IN ACCESS EXCLUSIVE MODE NOWAIT").Error; err != nil {
+				return err
+			}
+			var used bool
+			if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM " + name + ")").Scan(&used).Error; err != nil {
+				return err
+			}
+			if used {
+				return nil
+			}
+			if err := tx.Exec("DROP TABLE " + name).Error; err != nil {
+				return err
+			}
+			dropped = append(dropped, name)
+			return nil
+		})
+		if err != nil {
+			return dropped, err
+		}
+	}
+	return dropped, nil
+}
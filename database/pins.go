@@ -0,0 +1,112 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Pin errors
+var (
+	ErrAlreadyPinned = errors.New("message is already pinned")
+	ErrNotPinned     = errors.New("message is not pinned")
+	ErrPinLimit      = errors.New("too many pinned messages in this conversation")
+)
+
+// MessagePin model for GORM (a message pinned in its conversation)
+type MessagePin struct {
+	ID           uint      `gorm:"primaryKey"`
+	MessageID    uint      `gorm:"uniqueIndex;not null"`
+	Message      Message   `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE"`
+	Conversation string    `gorm:"size:120;not null;index"` // see PinConversation
+	PinnedBy     string    `gorm:"size:50;not null"`
+	PinnedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (MessagePin) TableName() string {
+	return "message_pins"
+}
+
+// PinnedMessage is a pinned message with who pinned it
+type PinnedMessage struct {
+	Message  `gorm:"embedded"`
+	PinnedBy string
+	PinnedAt time.Time
+}
+
+// PinConversation returns the key pins of a conversation share: the group, or
+// the pair of users of a private chat in a fixed order
+func PinConversation(groupID uint, user1, user2 string) string {
+	if groupID != 0 {
+		return fmt.Sprintf("group:%d", groupID)
+	}
+	if user1 > user2 {
+		user1, user2 = user2, user1
+	}
+	return "private:" + user1 + ":" + user2
+}
+
+// PinConversationOf returns the pin conversation of a message
+func PinConversationOf(m *Message) string {
+	if m.GroupID != nil {
+		return PinConversation(*m.GroupID, "", "")
+	}
+	return PinConversation(0, m.FromUser, m.ToTarget)
+}
+
+// PinMessage pins a live message in its conversation, allowing at most limit pins there
+func (db *DB) PinMessage(m *Message, pinnedBy string, limit int) error {
+	conversation := PinConversationOf(m)
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Serialize pins of the conversation so the limit holds under concurrent requests
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", conversation).Error; err != nil {
+			return err
+		}
+		if _, err := lockLiveMessage(tx, m.ID); err != nil {
+			return err
+		}
+
+		var pinned, count int64
+		if err := tx.Model(&MessagePin{}).Where("message_id = ?", m.ID).Count(&pinned).Error; err != nil {
+			return err
+		}
+		if pinned > 0 {
+			return ErrAlreadyPinned
+		}
+		if err := tx.Model(&MessagePin{}).Where("conversation = ?", conversation).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return ErrPinLimit
+		}
+		return tx.Create(&MessagePin{MessageID: m.ID, Conversation: conversation, PinnedBy: pinnedBy}).Error
+	})
+}
+
+// UnpinMessage removes the pin of a message
+func (db *DB) UnpinMessage(messageID uint) error {
+	result := db.Where("message_id = ?", messageID).Delete(&MessagePin{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotPinned
+	}
+	return nil
+}
+
+// ListPins returns the pinned messages of a conversation, most recently pinned first
+func (db *DB) ListPins(conversation string) ([]PinnedMessage, error) {
+	var pins []PinnedMessage
+	result := db.Table("message_pins p").
+		Select("m.*, p.pinned_by, p.pinned_at").
+		Joins("JOIN messages m ON m.id = p.message_id").
+		Where("p.conversation = ?", conversation).
+		Where(notExpiredIn("m")).
+		Order("p.pinned_at DESC, p.id DESC").
+		Scan(&pins)
+	return pins, result.Error
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// MessageReaction model for GORM (one row per message, user and emoji)
+type MessageReaction struct {
+	ID        uint      `gorm:"primaryKey"`
+	MessageID uint      `gorm:"not null;uniqueIndex:idx_message_reactions_unique"`
+	Username  string    `gorm:"size:50;not null;uniqueIndex:idx_message_reactions_unique"`
+	Emoji     string    `gorm:"size:32;not null;uniqueIndex:idx_message_reactions_unique"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	Message   Message   `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name
+func (MessageReaction) TableName() string {
+	return "message_reactions"
+}
+
+// ReactionCount is the number of users that reacted to a message with an emoji
+type ReactionCount struct {
+	MessageID uint
+	Emoji     string
+	Count     int
+	Mine      bool // the viewer is one of them
+}
+
+// AddReaction adds a reaction; added is false when the user already reacted with that emoji
+func (db *DB) AddReaction(messageID uint, username, emoji string) (bool, error) {
+	reaction := &MessageReaction{MessageID: messageID, Username: username, Emoji: emoji}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveReaction removes a reaction; removed is false when there was none
+func (db *DB) RemoveReaction(messageID uint, username, emoji string) (bool, error) {
+	result := db.Where("message_id = ? AND username = ? AND emoji = ?", messageID, username, emoji).
+		Delete(&MessageReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetReactionCounts aggregates the reactions of several messages in one query,
+// most used emoji first
+func (db *DB) GetReactionCounts(messageIDs []uint, viewer string) (map[uint][]ReactionCount, error) {
+	counts := make(map[uint][]ReactionCount)
+	if len(messageIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []ReactionCount
+	result := db.Model(&MessageReaction{}).
+		Select("message_id, emoji, COUNT(*) AS count, BOOL_OR(username = ?) AS mine", viewer).
+		Where("message_id IN ?", messageIDs).
+		Group("message_id, emoji").
+		Order("message_id, count DESC, MIN(created_at)").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, r := range rows {
+		counts[r.MessageID] = append(counts[r.MessageID], r)
+	}
+	return counts, nil
+}
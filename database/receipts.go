@@ -0,0 +1,100 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ReadCursor model for GORM: the last message a user has read in a conversation.
+// Group conversations set GroupID, private ones set Peer.
+type ReadCursor struct {
+	ID         uint      `gorm:"primaryKey"`
+	Username   string    `gorm:"size:50;not null;uniqueIndex:idx_read_cursors_conversation"`
+	GroupID    uint      `gorm:"not null;default:0;uniqueIndex:idx_read_cursors_conversation"`
+	Peer       string    `gorm:"size:50;not null;default:'';uniqueIndex:idx_read_cursors_conversation"`
+	LastReadID uint      `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (ReadCursor) TableName() string {
+	return "read_cursors"
+}
+
+// LatestGroupMessageID returns the ID of the newest message of a group, 0 if none
+func (db *DB) LatestGroupMessageID(groupID uint) (uint, error) {
+	var id uint
+	result := db.Model(&Message{}).Where("group_id = ?", groupID).Select("COALESCE(MAX(id), 0)").Scan(&id)
+	return id, result.Error
+}
+
+// LatestPrivateMessageID returns the ID of the newest private message between two users, 0 if none
+func (db *DB) LatestPrivateMessageID(user1, user2 string) (uint, error) {
+	var id uint
+	result := db.Model(&Message{}).
+		Where("message_type = 'private' AND ((from_user = ? AND to_target = ?) OR (from_user = ? AND to_target = ?))", user1, user2, user2, user1).
+		Select("COALESCE(MAX(id), 0)").Scan(&id)
+	return id, result.Error
+}
+
+// MarkRead moves the read cursor of a conversation forward to messageID.
+// Cursors never move back; advanced is false when it was already there.
+func (db *DB) MarkRead(username string, groupID uint, peer string, messageID uint) (bool, error) {
+	cursor := &ReadCursor{Username: username, GroupID: groupID, Peer: peer, LastReadID: messageID}
+	result := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "username"}, {Name: "group_id"}, {Name: "peer"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_read_id": messageID,
+			"updated_at":   time.Now(),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "read_cursors.last_read_id < ?", Vars: []interface{}{messageID}}}},
+	}).Create(cursor)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetGroupUnreadCounts counts, per group, messages of others newer than the read
+// cursor of username. Messages from before the user joined never count.
+func (db *DB) GetGroupUnreadCounts(username string, groupIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int)
+	if len(groupIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		GroupID uint
+		Unread  int
+	}
+	result := db.Raw(`
+		SELECT m.group_id, COUNT(*) AS unread
+		FROM messages m
+		JOIN group_members gm ON gm.group_id = m.group_id AND gm.username = ?
+		LEFT JOIN read_cursors rc ON rc.username = gm.username AND rc.group_id = m.group_id AND rc.peer = ''
+		WHERE m.group_id IN ?
+			AND m.from_user <> ?
+			AND m.deleted_at IS NULL
+			AND (m.expires_at IS NULL OR m.expires_at > NOW())
+			AND m.created_at > gm.joined_at
+			AND m.id > COALESCE(rc.last_read_id, 0)
+		GROUP BY m.group_id
+	`, username, groupIDs, username).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, r := range rows {
+		counts[r.GroupID] = r.Unread
+	}
+	return counts, nil
+}
+
+// SetReadReceipts turns sending read receipts of a user on or off
+func (db *DB) SetReadReceipts(username string, enabled bool) error {
+	return db.Model(&User{}).Where("username = ?", username).Update("read_receipts", enabled).Error
+}
+
+// GetReadCursors returns all read cursors of a user
+func (db *DB) GetReadCursors(username string) ([]ReadCursor, error) {
+	var cursors []ReadCursor
+	result := db.Where("username = ?", username).Find(&cursors)
+	return cursors, result.Error
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRetentionNotFound is returned when removing a policy that does not exist
+var ErrRetentionNotFound = errors.New("retention policy not found")
+
+// RetentionPolicy model for GORM: messages older than RetainDays are purged.
+// A policy covers one message type (GroupID 0) or one group (MessageType "");
+// for messages of a group with its own policy, the group policy wins.
+type RetentionPolicy struct {
+	ID          uint      `gorm:"primaryKey"`
+	MessageType string    `gorm:"size:20;not null;default:'';uniqueIndex:idx_retention_policies_scope"`
+	GroupID     uint      `gorm:"not null;default:0;uniqueIndex:idx_retention_policies_scope"`
+	RetainDays  int       `gorm:"not null"`
+	Archive     bool      `gorm:"not null;default:false"` // write purged messages to the archive first
+	UpdatedBy   string    `gorm:"size:50;not null"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (RetentionPolicy) TableName() string {
+	return "retention_policies"
+}
+
+// SetRetentionPolicy creates or replaces the policy of a message type or a group
+func (db *DB) SetRetentionPolicy(policy *RetentionPolicy) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_type"}, {Name: "group_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"retain_days", "archive", "updated_by", "updated_at"}),
+	}).Create(policy).Error
+}
+
+// DeleteRetentionPolicy removes the policy of a message type or a group, so its
+// messages are kept again
+func (db *DB) DeleteRetentionPolicy(messageType string, groupID uint) error {
+	result := db.Where("message_type = ? AND group_id = ?", messageType, groupID).Delete(&RetentionPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRetentionNotFound
+	}
+	return nil
+}
+
+// ListRetentionPolicies returns all policies, type policies first
+func (db *DB) ListRetentionPolicies() ([]RetentionPolicy, error) {
+	var policies []RetentionPolicy
+	result := db.Order("group_id ASC, message_type ASC").Find(&policies)
+	return policies, result.Error
+}
+
+// PurgeRetentionBatch purges up to limit messages that policy no longer keeps
+// as of now, passing them to archive first when it is set. It returns how many
+// were purged; fewer than limit means the policy is done for now.
+func (db *DB) PurgeRetentionBatch(policy *RetentionPolicy, now time.Time, limit int, archive func([]Message) error) (int, error) {
+	cutoff := now.AddDate(0, 0, -policy.RetainDays)
+
+	where, args := "group_id = ? AND created_at < ?", []interface{}{policy.GroupID, cutoff}
+	if policy.GroupID == 0 {
+		// Groups with their own policy are left to it
+		where = `message_type = ? AND created_at < ?
+			AND (group_id IS NULL OR group_id NOT IN (SELECT group_id FROM retention_policies WHERE group_id <> 0))`
+		args = []interface{}{policy.MessageType, cutoff}
+	}
+
+	purged, err := db.purgeBatch(where, args, limit, archive)
+	return len(purged), err
+}
+
+// purgeBatch hard-deletes up to limit messages matching where, oldest first,
+// and returns them. Each batch is a short transaction that locks only its own
+// rows and skips rows locked by others, so chat traffic and other purges go on.
+// archive, when set, sees the rows before they are deleted; if it fails they stay.
+func (db *DB) purgeBatch(where string, args []interface{}, limit int, archive func([]Message) error) ([]Message, error) {
+	var purged []Message
+	err := db.Transaction(func(tx *gorm.DB) error {
+		vars := append(append([]interface{}{}, args...), limit)
+		if err := tx.Raw("SELECT * FROM messages WHERE "+where+" ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED", vars...).Scan(&purged).Error; err != nil {
+			return err
+		}
+		if len(purged) == 0 {
+			return nil
+		}
+		if archive != nil {
+			if err := archive(purged); err != nil {
+				return err
+			}
+		}
+
+		ids := make([]uint, len(purged))
+		for i, m := range purged {
+			ids[i] = m.ID
+		}
+		return deleteMessageRows(tx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// deleteMessageRows hard-deletes messages with their edits, reactions, mentions
+// and pins. Rows referencing messages are removed here rather than by foreign
+// keys, which a partitioned messages table cannot have. Attachments are detached
+// and removed by the attachment cleanup.
+func deleteMessageRows(tx *gorm.DB, ids []uint) error {
+	if err := tx.Model(&Attachment{}).Where("message_id IN ?", ids).Update("message_id", nil).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&MessageEdit{}, &MessageReaction{}, &Mention{}, &MessagePin{}} {
+		if err := tx.Where("message_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("id IN ?", ids).Delete(&Message{}).Error
+}
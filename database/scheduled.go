@@ -0,0 +1,131 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status of scheduled messages
+const (
+	SchedulePending  = "pending"
+	ScheduleSending  = "sending" // claimed by a server instance
+	ScheduleSent     = "sent"
+	ScheduleCanceled = "canceled"
+	ScheduleFailed   = "failed"
+)
+
+// ErrScheduleNotFound is returned when a pending scheduled message does not exist
+var ErrScheduleNotFound = errors.New("scheduled message not found")
+
+// ScheduledMessage model for GORM (a message to send at a later time)
+type ScheduledMessage struct {
+	ID        uint      `gorm:"primaryKey"`
+	Username  string    `gorm:"size:50;not null;index"` // sender
+	ChatType  string    `gorm:"size:20;not null"`       // private or group
+	Target    string    `gorm:"size:100;not null"`      // peer, or group name when scheduled
+	GroupID   *uint     `gorm:"index"`
+	Text      string    `gorm:"type:text;not null"`
+	SendAt    time.Time `gorm:"not null;index:idx_scheduled_messages_due,priority:2"`
+	Status    string    `gorm:"size:20;not null;default:'pending';index:idx_scheduled_messages_due,priority:1"`
+	Error     string    `gorm:"size:255"`
+	MessageID *uint     // message created when sent
+	ClaimedAt *time.Time
+	SentAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// CreateScheduledMessage stores a message to send later
+func (db *DB) CreateScheduledMessage(m *ScheduledMessage) error {
+	m.Status = SchedulePending
+	return db.Create(m).Error
+}
+
+// CountPendingScheduled counts the pending scheduled messages of a user
+func (db *DB) CountPendingScheduled(username string) (int64, error) {
+	var count int64
+	result := db.Model(&ScheduledMessage{}).Where("username = ? AND status = ?", username, SchedulePending).Count(&count)
+	return count, result.Error
+}
+
+// ListScheduledMessages returns the pending scheduled messages of a user, next due first
+func (db *DB) ListScheduledMessages(username string) ([]ScheduledMessage, error) {
+	var scheduled []ScheduledMessage
+	result := db.Where("username = ? AND status = ?", username, SchedulePending).Order("send_at ASC, id ASC").Find(&scheduled)
+	return scheduled, result.Error
+}
+
+// CancelScheduledMessage cancels a pending scheduled message of a user
+func (db *DB) CancelScheduledMessage(id uint, username string) error {
+	result := db.Model(&ScheduledMessage{}).
+		Where("id = ? AND username = ? AND status = ?", id, username, SchedulePending).
+		Update("status", ScheduleCanceled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+// ClaimDueScheduled marks up to limit due messages as sending and returns them.
+// Rows locked by another server instance are skipped, so each message is claimed once.
+func (db *DB) ClaimDueScheduled(now time.Time, limit int) ([]ScheduledMessage, error) {
+	var claimed []ScheduledMessage
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`
+			SELECT * FROM scheduled_messages
+			WHERE status = ? AND send_at <= ?
+			ORDER BY send_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		`, SchedulePending, now, limit).Scan(&claimed).Error; err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(claimed))
+		for i := range claimed {
+			ids[i] = claimed[i].ID
+		}
+		return tx.Model(&ScheduledMessage{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": ScheduleSending, "claimed_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// FinishScheduled records the outcome of a claimed message: failed with reason,
+// or sent when reason is empty. messageID is 0 when the message was delivered
+// but could not be stored.
+func (db *DB) FinishScheduled(id, messageID uint, reason string) error {
+	updates := map[string]interface{}{"status": ScheduleFailed, "error": reason}
+	if reason == "" {
+		updates = map[string]interface{}{"status": ScheduleSent, "sent_at": time.Now()}
+		if messageID != 0 {
+			updates["message_id"] = messageID
+		}
+	}
+	return db.Model(&ScheduledMessage{}).Where("id = ? AND status = ?", id, ScheduleSending).Updates(updates).Error
+}
+
+// FailStaleScheduled fails messages claimed before a time and never finished,
+// e.g. when the server stopped while sending. They are not retried: the
+// message may already have been delivered.
+func (db *DB) FailStaleScheduled(before time.Time) (int64, error) {
+	result := db.Model(&ScheduledMessage{}).
+		Where("status = ? AND claimed_at < ?", ScheduleSending, before).
+		Updates(map[string]interface{}{"status": ScheduleFailed, "error": "interrupted while sending"})
+	return result.RowsAffected, result.Error
+}
@@ -19,14 +19,49 @@ CREATE TABLE IF NOT EXISTS users (
     display_name VARCHAR(100),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     last_seen TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-    is_online BOOLEAN DEFAULT FALSE
+    is_online BOOLEAN DEFAULT FALSE,
+    is_bot BOOLEAN DEFAULT FALSE,
+    bot_owner VARCHAR(50), -- user that created the bot
+    role VARCHAR(20) NOT NULL DEFAULT 'user', -- 'user', 'moderator' or 'admin'
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    read_receipts BOOLEAN NOT NULL DEFAULT TRUE -- others see when this user read their messages
+);
+
+-- Workspaces: tenants isolating users and groups
+CREATE TABLE IF NOT EXISTS workspaces (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_by VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Workspace members (a user can belong to several workspaces)
+CREATE TABLE IF NOT EXISTS workspace_members (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member', -- owner, admin, member
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(workspace_id, username)
 );
 
 -- Groups table
 CREATE TABLE IF NOT EXISTS groups (
     id SERIAL PRIMARY KEY,
-    name VARCHAR(100) UNIQUE NOT NULL,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL, -- handle, unique per workspace, can be renamed; id is the stable key
+    display_name VARCHAR(100),
+    topic VARCHAR(255),
+    description TEXT,
+    kind VARCHAR(20) NOT NULL DEFAULT 'group', -- group, channel (only admins post)
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public', -- public, private, invite_only
+    post_policy VARCHAR(20) NOT NULL DEFAULT 'members', -- who can post: members, admins
+    invite_policy VARCHAR(20) NOT NULL DEFAULT 'admins', -- who can invite: members, admins
+    mention_policy VARCHAR(20) NOT NULL DEFAULT 'admins', -- who can mention @all: members, admins
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(workspace_id, name)
 );
 
 -- Group members table (many-to-many relationship)
@@ -34,6 +69,8 @@ CREATE TABLE IF NOT EXISTS group_members (
     id SERIAL PRIMARY KEY,
     group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
     username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member', -- owner, admin, member
+    joined_via INTEGER, -- group_invite_codes.id used to join
     joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     UNIQUE(group_id, username)
 );
@@ -42,16 +79,237 @@ CREATE TABLE IF NOT EXISTS group_members (
 CREATE TABLE IF NOT EXISTS messages (
     id SERIAL PRIMARY KEY,
     from_user VARCHAR(50) NOT NULL,
-    to_target VARCHAR(100) NOT NULL, -- username for private, group name for group
-    message_type VARCHAR(20) NOT NULL, -- 'private' or 'group'
+    to_target VARCHAR(100) NOT NULL, -- username for private, group name (at send time) for group
+    group_id INTEGER REFERENCES groups(id) ON DELETE CASCADE, -- set for group and system messages
+    message_type VARCHAR(20) NOT NULL, -- 'private', 'group' or 'system'
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE, -- tombstone: text is cleared
+    deleted_by VARCHAR(50),
+    reply_to INTEGER REFERENCES messages(id) ON DELETE SET NULL, -- message this one answers
+    thread_root INTEGER REFERENCES messages(id) ON DELETE CASCADE, -- NULL for top-level messages
+    reply_count INTEGER NOT NULL DEFAULT 0, -- on thread roots
+    last_reply_at TIMESTAMP WITH TIME ZONE,
+    last_reply_by VARCHAR(50),
+    mentions TEXT, -- JSON mention entities of group messages
+    expire_ttl BIGINT NOT NULL DEFAULT 0, -- disappearing message: seconds to live, 0 = kept
+    expires_at TIMESTAMP WITH TIME ZONE, -- set when sent, or when first read in 'read' mode
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED -- full-text search
+);
+
+-- Password reset tokens (admin-issued, one-time use; only the sha256 hash is stored)
+CREATE TABLE IF NOT EXISTS password_resets (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    issued_by VARCHAR(50),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Login sessions (bearer token hash, revocable)
+CREATE TABLE IF NOT EXISTS sessions (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- API keys (long-lived, scoped; only the sha256 hash is stored)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    created_by VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes VARCHAR(255) NOT NULL, -- comma separated: read, chat, groups
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Audit log of administrative actions
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id SERIAL PRIMARY KEY,
+    actor VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(100),
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Group invitations table
+CREATE TABLE IF NOT EXISTS group_invitations (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    invited_by VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, declined
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Join requests for private groups
+CREATE TABLE IF NOT EXISTS group_join_requests (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, declined
+    reviewed_by VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Shareable invite codes
+CREATE TABLE IF NOT EXISTS group_invite_codes (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    code VARCHAR(32) UNIQUE NOT NULL,
+    created_by VARCHAR(50) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE, -- NULL = never
+    max_uses INTEGER NOT NULL DEFAULT 0, -- 0 = unlimited
+    uses INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Group bans (one row per group and user)
+CREATE TABLE IF NOT EXISTS group_bans (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    banned_by VARCHAR(50) NOT NULL,
+    reason VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE, -- NULL = permanent
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(group_id, username)
+);
+
+-- Previous versions of edited messages
+CREATE TABLE IF NOT EXISTS message_edits (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    old_text TEXT NOT NULL,
+    edited_by VARCHAR(50) NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Emoji reactions, one per message, user and emoji
+CREATE TABLE IF NOT EXISTS message_reactions (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_id, username, emoji)
+);
+
+-- Read cursors: last message read per user and conversation
+CREATE TABLE IF NOT EXISTS read_cursors (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL DEFAULT 0, -- set for group conversations
+    peer VARCHAR(50) NOT NULL DEFAULT '', -- set for private conversations
+    last_read_id INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(username, group_id, peer)
+);
+
+-- Muted conversations
+CREATE TABLE IF NOT EXISTS conversation_mutes (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL DEFAULT 0, -- set for group conversations
+    peer VARCHAR(50) NOT NULL DEFAULT '', -- set for private conversations
+    muted_until TIMESTAMP WITH TIME ZONE, -- NULL = until unmuted
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(username, group_id, peer)
+);
+
+-- Uploaded files; contents live in the blob store under storage_key
+CREATE TABLE IF NOT EXISTS attachments (
+    id SERIAL PRIMARY KEY,
+    storage_key VARCHAR(64) UNIQUE NOT NULL,
+    uploader VARCHAR(50) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    message_id INTEGER REFERENCES messages(id) ON DELETE SET NULL, -- NULL until sent, or after the message is deleted
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mentions inbox: one row per mentioned member of a group message
+CREATE TABLE IF NOT EXISTS mentions (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL,
+    kind VARCHAR(10) NOT NULL, -- 'user', 'here' or 'all'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_id, username)
+);
+
+-- Pinned messages; conversation is 'group:<id>' or 'private:<user1>:<user2>' (sorted)
+CREATE TABLE IF NOT EXISTS message_pins (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER UNIQUE NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    conversation VARCHAR(120) NOT NULL,
+    pinned_by VARCHAR(50) NOT NULL,
+    pinned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Messages to send later; status is 'pending', 'sending' (claimed by a server), 'sent', 'canceled' or 'failed'
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL,
+    chat_type VARCHAR(20) NOT NULL,
+    target VARCHAR(100) NOT NULL,
+    group_id INTEGER,
     text TEXT NOT NULL,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error VARCHAR(255),
+    message_id INTEGER,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    sent_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Disappearing messages per conversation ('group:<id>' or 'private:<user1>:<user2>', like message_pins)
+CREATE TABLE IF NOT EXISTS conversation_ttls (
+    id SERIAL PRIMARY KEY,
+    conversation VARCHAR(120) UNIQUE NOT NULL,
+    ttl_seconds BIGINT NOT NULL,
+    mode VARCHAR(10) NOT NULL DEFAULT 'sent', -- 'sent' or 'read'
+    set_by VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Message retention: a policy per message type (group_id 0) or per group (message_type ''); the group policy wins
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id SERIAL PRIMARY KEY,
+    message_type VARCHAR(20) NOT NULL DEFAULT '',
+    group_id INTEGER NOT NULL DEFAULT 0,
+    retain_days INTEGER NOT NULL,
+    archive BOOLEAN NOT NULL DEFAULT FALSE, -- write purged messages to the archive first
+    updated_by VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(message_type, group_id)
+);
+
 -- Create indexes for efficient searching
 CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
 CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING gin(username gin_trgm_ops);
 CREATE INDEX IF NOT EXISTS idx_users_display_name_trgm ON users USING gin(display_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_groups_name_trgm ON groups USING gin(name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_groups_display_name_trgm ON groups USING gin(display_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_groups_description_trgm ON groups USING gin(description gin_trgm_ops);
 CREATE INDEX IF NOT EXISTS idx_users_online ON users(is_online);
 CREATE INDEX IF NOT EXISTS idx_groups_name ON groups(name);
 CREATE INDEX IF NOT EXISTS idx_group_members_group ON group_members(group_id);
@@ -59,6 +317,40 @@ CREATE INDEX IF NOT EXISTS idx_group_members_username ON group_members(username)
 CREATE INDEX IF NOT EXISTS idx_messages_from ON messages(from_user);
 CREATE INDEX IF NOT EXISTS idx_messages_to ON messages(to_target);
 CREATE INDEX IF NOT EXISTS idx_messages_type ON messages(message_type);
+CREATE INDEX IF NOT EXISTS idx_password_resets_username ON password_resets(username);
+CREATE INDEX IF NOT EXISTS idx_sessions_username ON sessions(username);
+CREATE INDEX IF NOT EXISTS idx_api_keys_username ON api_keys(username);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+CREATE INDEX IF NOT EXISTS idx_group_invitations_group ON group_invitations(group_id);
+CREATE INDEX IF NOT EXISTS idx_group_invitations_username ON group_invitations(username);
+CREATE INDEX IF NOT EXISTS idx_group_join_requests_group ON group_join_requests(group_id);
+CREATE INDEX IF NOT EXISTS idx_group_join_requests_username ON group_join_requests(username);
+CREATE INDEX IF NOT EXISTS idx_group_invite_codes_group ON group_invite_codes(group_id);
+CREATE INDEX IF NOT EXISTS idx_group_members_joined_via ON group_members(joined_via);
+CREATE INDEX IF NOT EXISTS idx_messages_group ON messages(group_id);
+CREATE INDEX IF NOT EXISTS idx_messages_group_created ON messages(group_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created ON audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_username ON workspace_members(username);
+CREATE INDEX IF NOT EXISTS idx_message_edits_message ON message_edits(message_id);
+CREATE INDEX IF NOT EXISTS idx_messages_thread_root ON messages(thread_root, id);
+CREATE INDEX IF NOT EXISTS idx_messages_reply_to ON messages(reply_to);
+CREATE INDEX IF NOT EXISTS idx_messages_private_to ON messages(to_target, from_user, id) WHERE message_type = 'private';
+CREATE INDEX IF NOT EXISTS idx_messages_private_from ON messages(from_user, id) WHERE message_type = 'private';
+CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING gin(search_vector);
+CREATE INDEX IF NOT EXISTS idx_attachments_uploader ON attachments(uploader);
+CREATE INDEX IF NOT EXISTS idx_attachments_message ON attachments(message_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_created ON attachments(created_at);
+CREATE INDEX IF NOT EXISTS idx_mentions_username_message ON mentions(username, message_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_group ON mentions(group_id);
+CREATE INDEX IF NOT EXISTS idx_message_pins_conversation ON message_pins(conversation);
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_username ON scheduled_messages(username);
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_group_id ON scheduled_messages(group_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_due ON scheduled_messages(status, send_at);
+CREATE INDEX IF NOT EXISTS idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_messages_type_created ON messages(message_type, created_at);
+
+-- messages can be converted to monthly partitions with "server -partition-messages"
 
 -- Function to search users (case-insensitive, fuzzy)
 CREATE OR REPLACE FUNCTION search_users(search_query TEXT)
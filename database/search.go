@@ -0,0 +1,71 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// searchConfig is the text search configuration of messages. 'simple' does no
+// stemming, which suits mixed Vietnamese / English chat.
+const searchConfig = "simple"
+
+// MessageSearch holds the query and filters of a message search
+type MessageSearch struct {
+	Viewer  string // only conversations the viewer takes part in
+	Query   string // web search syntax: words, "phrases", -excluded, or
+	From    string
+	GroupID uint   // restrict to a group the caller already checked access to
+	Peer    string // restrict to the private chat with peer
+	After   *time.Time
+	Before  *time.Time
+	Offset  int
+	Limit   int
+}
+
+// MessageHit is a search result with a highlighted snippet
+type MessageHit struct {
+	Message `gorm:"embedded"`
+	Snippet string
+	Rank    float64
+}
+
+// createSearchIndex adds the generated tsvector column of messages and its GIN index
+func createSearchIndex(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (to_tsvector('" + searchConfig + "', text)) STORED").Error; err != nil {
+		return err
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING gin(search_vector)").Error
+}
+
+// SearchMessages runs a full-text search over live messages, best match first
+func (db *DB) SearchMessages(search MessageSearch) ([]MessageHit, error) {
+	query := db.Table("messages AS m, websearch_to_tsquery('"+searchConfig+"', ?) AS q", search.Query).
+		Select("m.*, ts_headline('" + searchConfig + "', m.text, q, 'StartSel=**, StopSel=**, MaxWords=20, MinWords=5, MaxFragments=2') AS snippet, ts_rank(m.search_vector, q) AS rank").
+		Where("m.search_vector @@ q AND m.deleted_at IS NULL").
+		Where(notExpiredIn("m"))
+
+	switch {
+	case search.GroupID != 0:
+		query = query.Where("m.group_id = ?", search.GroupID)
+	case search.Peer != "":
+		query = query.Where("m.message_type = 'private' AND ((m.from_user = ? AND m.to_target = ?) OR (m.from_user = ? AND m.to_target = ?))",
+			search.Viewer, search.Peer, search.Peer, search.Viewer)
+	default:
+		query = query.Where("m.group_id IN (SELECT group_id FROM group_members WHERE username = ?) OR (m.message_type = 'private' AND (m.from_user = ? OR m.to_target = ?))",
+			search.Viewer, search.Viewer, search.Viewer)
+	}
+	if search.From != "" {
+		query = query.Where("m.from_user = ?", search.From)
+	}
+	if search.After != nil {
+		query = query.Where("m.created_at >= ?", *search.After)
+	}
+	if search.Before != nil {
+		query = query.Where("m.created_at < ?", *search.Before)
+	}
+
+	var hits []MessageHit
+	result := query.Order("rank DESC, m.id DESC").Offset(search.Offset).Limit(search.Limit).Scan(&hits)
+	return hits, result.Error
+}
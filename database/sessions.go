@@ -0,0 +1,105 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSession is returned when a session token is unknown, revoked or expired
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// Session model for GORM (server-side login sessions)
+type Session struct {
+	ID        uint      `gorm:"primaryKey"`
+	Username  string    `gorm:"size:50;not null;index"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"` // sha256 of the bearer token
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
+
+// TableName specifies the table name
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// CreateSession starts a new session for a user and returns it with its bearer token
+func (db *DB) CreateSession(username string, ttl time.Duration) (*Session, string, error) {
+	token, err := GenerateToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session := &Session{
+		Username:  username,
+		TokenHash: HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := db.Create(session).Error; err != nil {
+		return nil, "", err
+	}
+	return session, token, nil
+}
+
+// GetActiveSession looks up a session by bearer token, ignoring revoked and expired ones
+func (db *DB) GetActiveSession(token string) (*Session, error) {
+	var session Session
+	result := db.Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", HashToken(token), time.Now()).
+		First(&session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidSession
+		}
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// ListSessions returns the active sessions of a user, newest first
+func (db *DB) ListSessions(username string) ([]Session, error) {
+	var sessions []Session
+	result := db.Where("username = ? AND revoked_at IS NULL AND expires_at > ?", username, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes one session of a user
+func (db *DB) RevokeSession(username string, sessionID uint) error {
+	result := db.Model(&Session{}).
+		Where("id = ? AND username = ? AND revoked_at IS NULL", sessionID, username).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidSession
+	}
+	return nil
+}
+
+// RevokeUserSessions revokes every active session of a user except keepID (0 keeps none).
+// It returns the IDs of the revoked sessions so live connections can be closed.
+func (db *DB) RevokeUserSessions(username string, keepID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Session{}).
+			Where("username = ? AND id <> ? AND revoked_at IS NULL", username, keepID).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&Session{}).Where("id IN ?", ids).Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidReply is returned when a reply targets a message of another conversation
+var ErrInvalidReply = errors.New("can only reply to a message of the same conversation")
+
+// Root returns the ID of the thread a message belongs to, its own ID for top-level messages
+func (m *Message) Root() uint {
+	if m.ThreadRoot != nil {
+		return *m.ThreadRoot
+	}
+	return m.ID
+}
+
+// SaveReply saves reply as an answer to parent and bumps the reply count and
+// last-reply metadata of the thread root
+func (db *DB) SaveReply(reply *Message, parent *Message) error {
+	if parent.Deleted() {
+		return ErrMessageDeleted
+	}
+	root := parent.Root()
+	reply.ReplyTo = &parent.ID
+	reply.ThreadRoot = &root
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := applyConversationTTL(tx, reply); err != nil {
+			return err
+		}
+		if err := tx.Create(reply).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Message{}).Where("id = ?", root).Updates(map[string]interface{}{
+			"reply_count":   gorm.Expr("reply_count + 1"),
+			"last_reply_at": time.Now(),
+			"last_reply_by": reply.FromUser,
+		}).Error
+	})
+}
+
+// GetThreadReplies returns replies of a thread with an ID above afterID, oldest first
+func (db *DB) GetThreadReplies(rootID, afterID uint, limit int) ([]Message, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var messages []Message
+	result := db.Where("thread_root = ? AND id > ?", rootID, afterID).
+		Where(notExpired).
+		Order("id ASC").
+		Limit(limit).
+		Find(&messages)
+	return messages, result.Error
+}
+
+// GetThreadParticipants returns the author of a thread root and everyone who replied
+func (db *DB) GetThreadParticipants(rootID uint) ([]string, error) {
+	var users []string
+	result := db.Model(&Message{}).
+		Where("(id = ? OR thread_root = ?) AND message_type <> 'system'", rootID, rootID).
+		Distinct("from_user").
+		Pluck("from_user", &users)
+	return users, result.Error
+}
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultWorkspaceSlug is the workspace existing users and groups are moved
+// into, and that new accounts join
+const DefaultWorkspaceSlug = "default"
+
+// Roles of a member inside a workspace
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+var (
+	// ErrNotWorkspaceMember is returned when a user is not in a workspace
+	ErrNotWorkspaceMember = errors.New("not a member of this workspace")
+	// ErrAmbiguousGroup is returned when a group name exists in several workspaces
+	ErrAmbiguousGroup = errors.New("group name exists in several workspaces, use workspace/name")
+)
+
+// Workspace model for GORM (a tenant: users and groups are partitioned by workspace)
+type Workspace struct {
+	ID        uint      `gorm:"primaryKey"`
+	Slug      string    `gorm:"uniqueIndex;size:50;not null"`
+	Name      string    `gorm:"size:100;not null"`
+	CreatedBy string    `gorm:"size:50"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceMember model for GORM (users belong to one or more workspaces)
+type WorkspaceMember struct {
+	ID          uint      `gorm:"primaryKey"`
+	WorkspaceID uint      `gorm:"not null;uniqueIndex:idx_workspace_members_ws_user"`
+	Username    string    `gorm:"size:50;not null;uniqueIndex:idx_workspace_members_ws_user;index"`
+	Role        string    `gorm:"size:20;not null;default:'member'"` // owner, admin or member
+	JoinedAt    time.Time `gorm:"autoCreateTime"`
+	Workspace   Workspace `gorm:"foreignKey:WorkspaceID"`
+}
+
+// TableName specifies the table name
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// migrateWorkspaces creates the default workspace and moves users without a
+// workspace and groups without one into it. Group names become unique per
+// workspace instead of globally.
+func migrateWorkspaces(db *gorm.DB) error {
+	ws := Workspace{Slug: DefaultWorkspaceSlug, Name: "Default"}
+	if err := db.Where("slug = ?", ws.Slug).FirstOrCreate(&ws).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		INSERT INTO workspace_members (workspace_id, username, role, joined_at)
+		SELECT ?, u.username, 'member', NOW()
+		FROM users u
+		WHERE NOT EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.username = u.username)
+	`, ws.ID).Error; err != nil {
+		return err
+	}
+	if err := db.Exec("UPDATE groups SET workspace_id = ? WHERE workspace_id IS NULL", ws.ID).Error; err != nil {
+		return err
+	}
+
+	// Bỏ unique toàn cục trên groups.name (từ schema.sql hoặc GORM cũ)
+	db.Exec("ALTER TABLE groups DROP CONSTRAINT IF EXISTS groups_name_key")
+	db.Exec("DROP INDEX IF EXISTS idx_groups_name")
+	return nil
+}
+
+// CreateWorkspace creates a workspace owned by createdBy
+func (db *DB) CreateWorkspace(slug, name, createdBy string) (*Workspace, error) {
+	ws := &Workspace{Slug: slug, Name: name, CreatedBy: createdBy}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ws).Error; err != nil {
+			return err
+		}
+		return tx.Create(&WorkspaceMember{WorkspaceID: ws.ID, Username: createdBy, Role: WorkspaceRoleOwner}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// GetWorkspaceBySlug gets a workspace by slug
+func (db *DB) GetWorkspaceBySlug(slug string) (*Workspace, error) {
+	var ws Workspace
+	result := db.Where("slug = ?", slug).First(&ws)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &ws, nil
+}
+
+// GetUserWorkspaces returns the workspace memberships of a user with their workspaces
+func (db *DB) GetUserWorkspaces(username string) ([]WorkspaceMember, error) {
+	var members []WorkspaceMember
+	result := db.Preload("Workspace").Where("username = ?", username).Order("joined_at ASC").Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// GetUserWorkspaceIDs returns the IDs of the workspaces a user belongs to
+func (db *DB) GetUserWorkspaceIDs(username string) ([]uint, error) {
+	var ids []uint
+	result := db.Model(&WorkspaceMember{}).Where("username = ?", username).Pluck("workspace_id", &ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ids, nil
+}
+
+// GetWorkspaceRole returns the role of a user in a workspace, or ErrNotWorkspaceMember
+func (db *DB) GetWorkspaceRole(workspaceID uint, username string) (string, error) {
+	var member WorkspaceMember
+	result := db.Where("workspace_id = ? AND username = ?", workspaceID, username).First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return "", ErrNotWorkspaceMember
+		}
+		return "", result.Error
+	}
+	return member.Role, nil
+}
+
+// CountWorkspaceMembers returns the number of members of a workspace
+func (db *DB) CountWorkspaceMembers(workspaceID uint) (int64, error) {
+	var count int64
+	result := db.Model(&WorkspaceMember{}).Where("workspace_id = ?", workspaceID).Count(&count)
+	return count, result.Error
+}
+
+// AddWorkspaceMember adds a user to a workspace; existing members are left as is
+func (db *DB) AddWorkspaceMember(workspaceID uint, username, role string) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&WorkspaceMember{
+		WorkspaceID: workspaceID,
+		Username:    username,
+		Role:        role,
+	}).Error
+}
+
+// JoinDefaultWorkspace adds a new account to the default workspace
+func (db *DB) JoinDefaultWorkspace(username string) error {
+	ws, err := db.GetWorkspaceBySlug(DefaultWorkspaceSlug)
+	if err != nil {
+		return err
+	}
+	return db.AddWorkspaceMember(ws.ID, username, WorkspaceRoleMember)
+}
+
+// CopyWorkspaceMemberships adds username to every workspace of from (used for bots)
+func (db *DB) CopyWorkspaceMemberships(from, username string) error {
+	return db.Exec(`
+		INSERT INTO workspace_members (workspace_id, username, role, joined_at)
+		SELECT workspace_id, ?, 'member', NOW() FROM workspace_members WHERE username = ?
+		ON CONFLICT DO NOTHING
+	`, username, from).Error
+}
+
+// RemoveWorkspaceMember removes a user from a workspace together with their
+// memberships in the groups of that workspace. Groups they owned pass to a
+// successor as in LeaveGroup; groups left empty are deleted.
+func (db *DB) RemoveWorkspaceMember(workspaceID uint, username string) error {
+	var emptied []uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("workspace_id = ? AND username = ?", workspaceID, username).Delete(&WorkspaceMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotWorkspaceMember
+		}
+		var err error
+		emptied, err = leaveGroups(tx, username, "group_id IN (SELECT id FROM groups WHERE workspace_id = ?)", workspaceID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return db.deleteGroups(emptied)
+}
+
+// SharesWorkspace reports whether two users have at least one workspace in common
+func (db *DB) SharesWorkspace(user1, user2 string) (bool, error) {
+	var count int64
+	result := db.Raw(`
+		SELECT COUNT(*) FROM workspace_members a
+		JOIN workspace_members b ON a.workspace_id = b.workspace_id
+		WHERE a.username = ? AND b.username = ?
+	`, user1, user2).Scan(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// GetWorkspacePeers returns which of usernames share a workspace with username
+func (db *DB) GetWorkspacePeers(username string, usernames []string) ([]string, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	var peers []string
+	result := db.Raw(`
+		SELECT DISTINCT b.username FROM workspace_members a
+		JOIN workspace_members b ON a.workspace_id = b.workspace_id
+		WHERE a.username = ? AND b.username IN ?
+	`, username, usernames).Scan(&peers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return peers, nil
+}
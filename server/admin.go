@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Role tối thiểu để gọi từng RPC của AdminService
+var adminMethodRoles = map[string]string{
+	pb.AdminService_ListUsers_FullMethodName:             database.RoleModerator,
+	pb.AdminService_DisableUser_FullMethodName:           database.RoleModerator,
+	pb.AdminService_EnableUser_FullMethodName:            database.RoleModerator,
+	pb.AdminService_ForceDisconnect_FullMethodName:       database.RoleModerator,
+	pb.AdminService_PurgeMessages_FullMethodName:         database.RoleModerator,
+	pb.AdminService_DeleteUser_FullMethodName:            database.RoleAdmin,
+	pb.AdminService_SetUserRole_FullMethodName:           database.RoleAdmin,
+	pb.AdminService_IssuePasswordReset_FullMethodName:    database.RoleAdmin,
+	pb.AdminService_DeleteGroup_FullMethodName:           database.RoleAdmin,
+	pb.AdminService_ListAuditLog_FullMethodName:          database.RoleAdmin,
+	pb.AdminService_SetRetentionPolicy_FullMethodName:    database.RoleAdmin,
+	pb.AdminService_ListRetentionPolicies_FullMethodName: database.RoleAdmin,
+}
+
+// adminRoleInterceptor runs after authentication and rejects AdminService
+// calls from users without the required role
+func (s *chatServer) adminRoleInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	required, ok := adminMethodRoles[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	auth := authFromContext(ctx)
+	if !database.RoleAtLeast(auth.role, required) {
+		recordAudit(auth.username, "permission_denied", info.FullMethod, "role "+auth.role)
+		return nil, status.Errorf(codes.PermissionDenied, "requires role %s", required)
+	}
+	return handler(ctx, req)
+}
+
+// recordAudit writes an audit entry; failures are logged but do not fail the request
+func recordAudit(actor, action, target, details string) {
+	if err := db.RecordAudit(actor, action, target, details); err != nil {
+		log.Printf("Error recording audit log (%s %s %s): %v", actor, action, target, err)
+	}
+}
+
+// adminServer implements AdminService on top of the chat server state
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+	chat *chatServer
+}
+
+// manageableUser loads target and checks the caller outranks it
+func manageableUser(auth *authInfo, target string) (*database.User, error) {
+	if target == auth.username {
+		return nil, errors.New("cannot perform this action on your own account")
+	}
+
+	user, err := db.GetUserByUsername(target)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %s not found", target)
+		}
+		return nil, err
+	}
+
+	// Chỉ quản lý được user có role thấp hơn: admin không khóa / xóa / hạ role admin khác
+	// (role admin đổi bằng -grant-role trên máy chủ), moderator chỉ quản lý user thường
+	if database.RoleAtLeast(user.Role, auth.role) {
+		return nil, fmt.Errorf("insufficient role to manage %s", target)
+	}
+	return user, nil
+}
+
+// ListUsers - Liệt kê mọi tài khoản (kể cả offline, bị khóa)
+func (a *adminServer) ListUsers(ctx context.Context, req *pb.AdminListUsersRequest) (*pb.AdminListUsersResponse, error) {
+	resp := &pb.AdminListUsersResponse{}
+
+	users, err := db.ListUsersPage(req.Query, req.DisabledOnly, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Printf("Error listing users for admin: %v", err)
+		return resp, nil
+	}
+
+	for _, u := range users {
+		resp.Users = append(resp.Users, &pb.AdminUserInfo{
+			Username:    u.Username,
+			DisplayName: u.DisplayName,
+			Role:        u.Role,
+			IsBot:       u.IsBot,
+			IsOnline:    u.IsOnline,
+			Disabled:    u.DisabledAt != nil,
+			CreatedAt:   u.CreatedAt.Unix(),
+			LastSeen:    u.LastSeen.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// DisableUser - Khóa tài khoản, hủy mọi session và ngắt kết nối
+func (a *adminServer) DisableUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if _, err := manageableUser(auth, req.Username); err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if _, err := db.SetUserDisabled(req.Username, true); err != nil {
+		log.Printf("Error disabling user %s: %v", req.Username, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to disable user"}, nil
+	}
+	a.chat.closeClients(func(c *clientSession) bool { return c.username == req.Username },
+		status.Error(codes.PermissionDenied, "account disabled"))
+
+	recordAudit(auth.username, "disable_user", req.Username, req.Reason)
+	log.Printf("User %s disabled by %s", req.Username, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "user disabled"}, nil
+}
+
+// EnableUser - Mở khóa tài khoản
+func (a *adminServer) EnableUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if _, err := manageableUser(auth, req.Username); err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if _, err := db.SetUserDisabled(req.Username, false); err != nil {
+		log.Printf("Error enabling user %s: %v", req.Username, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to enable user"}, nil
+	}
+
+	recordAudit(auth.username, "enable_user", req.Username, req.Reason)
+	log.Printf("User %s enabled by %s", req.Username, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "user enabled"}, nil
+}
+
+// DeleteUser - Xóa tài khoản (giữ lại lịch sử tin nhắn)
+func (a *adminServer) DeleteUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if _, err := manageableUser(auth, req.Username); err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	a.chat.closeClients(func(c *clientSession) bool { return c.username == req.Username },
+		status.Error(codes.Unauthenticated, "account deleted"))
+	if err := db.DeleteUser(req.Username); err != nil {
+		log.Printf("Error deleting user %s: %v", req.Username, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to delete user"}, nil
+	}
+
+	recordAudit(auth.username, "delete_user", req.Username, req.Reason)
+	log.Printf("User %s deleted by %s", req.Username, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "user deleted"}, nil
+}
+
+// SetUserRole - Đổi role server của user
+func (a *adminServer) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if !database.ValidRole(req.Role) {
+		return &pb.AdminResponse{Ok: false, Message: "role must be user, moderator or admin"}, nil
+	}
+	if _, err := manageableUser(auth, req.Username); err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.SetUserRole(req.Username, req.Role); err != nil {
+		log.Printf("Error setting role of %s: %v", req.Username, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to set role"}, nil
+	}
+
+	recordAudit(auth.username, "set_role", req.Username, req.Role)
+	log.Printf("Role of %s set to %s by %s", req.Username, req.Role, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "role updated"}, nil
+}
+
+// IssuePasswordReset - Cấp reset token cho user quên mật khẩu
+func (a *adminServer) IssuePasswordReset(ctx context.Context, req *pb.AdminUserRequest) (*pb.IssuePasswordResetResponse, error) {
+	auth := authFromContext(ctx)
+	user, err := manageableUser(auth, req.Username)
+	if err != nil {
+		return &pb.IssuePasswordResetResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if user.IsBot {
+		return &pb.IssuePasswordResetResponse{Ok: false, Message: "bots have no password"}, nil
+	}
+
+	ttl := time.Hour
+	token, err := db.CreatePasswordReset(req.Username, auth.username, ttl)
+	if err != nil {
+		log.Printf("Error issuing reset token for %s: %v", req.Username, err)
+		return &pb.IssuePasswordResetResponse{Ok: false, Message: "failed to issue reset token"}, nil
+	}
+
+	recordAudit(auth.username, "issue_password_reset", req.Username, req.Reason)
+	log.Printf("Password reset token for %s issued by %s", req.Username, auth.username)
+	return &pb.IssuePasswordResetResponse{
+		Ok:         true,
+		Message:    "reset token issued",
+		ResetToken: token,
+		ExpiresAt:  time.Now().Add(ttl).Unix(),
+	}, nil
+}
+
+// ForceDisconnect - Ngắt kết nối và hủy session của user
+func (a *adminServer) ForceDisconnect(ctx context.Context, req *pb.ForceDisconnectRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if _, err := manageableUser(auth, req.Username); err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	sessionID := uint(req.SessionId)
+	if sessionID == 0 {
+		a.chat.mu.RLock()
+		if c, ok := a.chat.clients[req.Username]; ok {
+			sessionID = c.sessionID
+		}
+		a.chat.mu.RUnlock()
+	}
+
+	if sessionID != 0 {
+		if err := db.RevokeSession(req.Username, sessionID); err != nil && !errors.Is(err, database.ErrInvalidSession) {
+			log.Printf("Error revoking session %d of %s: %v", sessionID, req.Username, err)
+			return &pb.AdminResponse{Ok: false, Message: "failed to revoke session"}, nil
+		}
+	}
+
+	reason := status.Error(codes.Aborted, "disconnected by administrator")
+	if req.Reason != "" {
+		reason = status.Errorf(codes.Aborted, "disconnected by administrator: %s", req.Reason)
+	}
+	a.chat.closeClients(func(c *clientSession) bool {
+		// Stream dùng API key không có session, ngắt theo username
+		return c.username == req.Username && (req.SessionId == 0 || c.sessionID == sessionID)
+	}, reason)
+
+	recordAudit(auth.username, "force_disconnect", req.Username, fmt.Sprintf("session %d: %s", sessionID, req.Reason))
+	log.Printf("User %s (session %d) force-disconnected by %s", req.Username, sessionID, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "disconnected"}, nil
+}
+
+// DeleteGroup - Xóa group cùng members và tin nhắn
+func (a *adminServer) DeleteGroup(ctx context.Context, req *pb.AdminGroupRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+
+	group, err := loadGroup(req.GroupId, req.GroupName, "")
+	if err != nil {
+		return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if err := db.DeleteGroup(group.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.AdminResponse{Ok: false, Message: "group not found"}, nil
+		}
+		log.Printf("Error deleting group %s: %v", group.Name, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to delete group"}, nil
+	}
+
+	recordAudit(auth.username, "delete_group", group.Name, req.Reason)
+	log.Printf("Group %s (#%d) deleted by %s", group.Name, group.ID, auth.username)
+	return &pb.AdminResponse{Ok: true, Message: "group deleted"}, nil
+}
+
+// PurgeMessages - Xóa tin nhắn theo người gửi và/hoặc group
+func (a *adminServer) PurgeMessages(ctx context.Context, req *pb.PurgeMessagesRequest) (*pb.PurgeMessagesResponse, error) {
+	auth := authFromContext(ctx)
+	if req.FromUser == "" && req.GroupName == "" && req.GroupId == 0 {
+		return &pb.PurgeMessagesResponse{Ok: false, Message: "from_user, group_id or group_name is required"}, nil
+	}
+
+	var groupID uint
+	groupName := ""
+	if req.GroupId != 0 || req.GroupName != "" {
+		group, err := loadGroup(req.GroupId, req.GroupName, "")
+		if err != nil {
+			return &pb.PurgeMessagesResponse{Ok: false, Message: err.Error()}, nil
+		}
+		groupID, groupName = group.ID, group.Name
+	}
+
+	before := time.Now()
+	if req.Before > 0 {
+		before = time.Unix(req.Before, 0)
+	}
+
+	deleted, err := db.PurgeMessages(req.FromUser, groupID, before)
+	if err != nil {
+		log.Printf("Error purging messages: %v", err)
+		return &pb.PurgeMessagesResponse{Ok: false, Message: "failed to purge messages"}, nil
+	}
+
+	details := fmt.Sprintf("from=%q group=%q before=%s deleted=%d: %s",
+		req.FromUser, groupName, before.Format(time.RFC3339), deleted, req.Reason)
+	recordAudit(auth.username, "purge_messages", req.FromUser+"/"+groupName, details)
+	log.Printf("%d messages purged by %s (%s)", deleted, auth.username, details)
+	return &pb.PurgeMessagesResponse{Ok: true, Message: "messages purged", Deleted: deleted}, nil
+}
+
+// ListAuditLog - Xem audit log
+func (a *adminServer) ListAuditLog(ctx context.Context, req *pb.ListAuditLogRequest) (*pb.ListAuditLogResponse, error) {
+	resp := &pb.ListAuditLogResponse{}
+
+	entries, err := db.ListAuditLog(req.Actor, req.Action, uint(req.BeforeId), int(req.Limit))
+	if err != nil {
+		log.Printf("Error listing audit log: %v", err)
+		return resp, nil
+	}
+
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditLogEntry{
+			Id:        int64(e.ID),
+			Actor:     e.Actor,
+			Action:    e.Action,
+			Target:    e.Target,
+			Details:   e.Details,
+			CreatedAt: e.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
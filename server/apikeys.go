@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Scopes của API key
+const (
+	scopeRead   = "read"   // list/search users, list groups
+	scopeChat   = "chat"   // ChatStream
+	scopeGroups = "groups" // create/join groups
+)
+
+var validScopes = []string{scopeRead, scopeChat, scopeGroups}
+
+// keyOwner resolves the account an API key request targets: the caller itself
+// or a bot owned by the caller
+func keyOwner(caller, target string) (string, error) {
+	if target == "" || target == caller {
+		return caller, nil
+	}
+
+	user, err := db.GetUserByUsername(target)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("user %s not found", target)
+		}
+		return "", err
+	}
+	if !user.IsBot || user.BotOwner != caller {
+		return "", fmt.Errorf("%s is not a bot owned by you", target)
+	}
+	return target, nil
+}
+
+func toAPIKeyInfo(k *database.APIKey) *pb.ApiKeyInfo {
+	info := &pb.ApiKeyInfo{
+		Id:        int64(k.ID),
+		Name:      k.Name,
+		Username:  k.Username,
+		Prefix:    k.Prefix,
+		Scopes:    k.ScopeList(),
+		CreatedAt: k.CreatedAt.Unix(),
+	}
+	if k.LastUsedAt != nil {
+		info.LastUsedAt = k.LastUsedAt.Unix()
+	}
+	return info
+}
+
+// CreateBot - Tạo tài khoản bot do user hiện tại sở hữu
+func (s *chatServer) CreateBot(ctx context.Context, req *pb.CreateBotRequest) (*pb.CreateBotResponse, error) {
+	caller := callerName(ctx)
+
+	if err := s.policy.validateUsername(req.Username); err != nil {
+		return &pb.CreateBotResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	exists, err := db.UserExists(req.Username)
+	if err != nil {
+		log.Printf("Error checking user existence: %v", err)
+		return &pb.CreateBotResponse{Ok: false, Message: "database error"}, nil
+	}
+	if exists {
+		return &pb.CreateBotResponse{Ok: false, Message: "username already exists"}, nil
+	}
+
+	if _, err := db.CreateBot(req.Username, req.DisplayName, caller); err != nil {
+		log.Printf("Error creating bot %s: %v", req.Username, err)
+		return &pb.CreateBotResponse{Ok: false, Message: "failed to create bot"}, nil
+	}
+	// Bot dùng chung các workspace của owner
+	if err := db.CopyWorkspaceMemberships(caller, req.Username); err != nil {
+		log.Printf("Error adding bot %s to workspaces of %s: %v", req.Username, caller, err)
+	}
+
+	log.Printf("Bot created: %s (owner: %s)", req.Username, caller)
+	return &pb.CreateBotResponse{Ok: true, Message: "bot created, create an api key for it"}, nil
+}
+
+// CreateApiKey - Tạo API key cho chính user hoặc bot của user
+func (s *chatServer) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	caller := callerName(ctx)
+
+	owner, err := keyOwner(caller, req.Username)
+	if err != nil {
+		return &pb.CreateApiKeyResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if req.Name == "" {
+		return &pb.CreateApiKeyResponse{Ok: false, Message: "empty key name"}, nil
+	}
+
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{scopeRead, scopeChat}
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(validScopes, scope) {
+			return &pb.CreateApiKeyResponse{Ok: false, Message: fmt.Sprintf("unknown scope %q", scope)}, nil
+		}
+	}
+
+	apiKey, key, err := db.CreateAPIKey(owner, caller, req.Name, scopes)
+	if err != nil {
+		log.Printf("Error creating api key for %s: %v", owner, err)
+		return &pb.CreateApiKeyResponse{Ok: false, Message: "failed to create api key"}, nil
+	}
+
+	log.Printf("API key %d (%s) created for %s by %s", apiKey.ID, apiKey.Prefix, owner, caller)
+	return &pb.CreateApiKeyResponse{
+		Ok:      true,
+		Message: "api key created, store it now: it will not be shown again",
+		Key:     key,
+		Info:    toAPIKeyInfo(apiKey),
+	}, nil
+}
+
+// ListApiKeys - Liệt kê API key còn hiệu lực
+func (s *chatServer) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	owner, err := keyOwner(callerName(ctx), req.Username)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	resp := &pb.ListApiKeysResponse{}
+	keys, err := db.ListAPIKeys(owner)
+	if err != nil {
+		log.Printf("Error listing api keys for %s: %v", owner, err)
+		return resp, nil
+	}
+	for i := range keys {
+		resp.Keys = append(resp.Keys, toAPIKeyInfo(&keys[i]))
+	}
+	return resp, nil
+}
+
+// RevokeApiKey - Thu hồi API key và ngắt các stream đang dùng key đó
+func (s *chatServer) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.RevokeApiKeyResponse, error) {
+	caller := callerName(ctx)
+	keyID := uint(req.KeyId)
+
+	apiKey, err := db.GetAPIKey(keyID)
+	if err != nil {
+		return &pb.RevokeApiKeyResponse{Ok: false, Message: "api key not found"}, nil
+	}
+	if _, err := keyOwner(caller, apiKey.Username); err != nil {
+		return &pb.RevokeApiKeyResponse{Ok: false, Message: "api key not found"}, nil
+	}
+
+	if err := db.RevokeAPIKey(keyID); err != nil {
+		if errors.Is(err, database.ErrInvalidAPIKey) {
+			return &pb.RevokeApiKeyResponse{Ok: false, Message: "api key already revoked"}, nil
+		}
+		log.Printf("Error revoking api key %d: %v", keyID, err)
+		return &pb.RevokeApiKeyResponse{Ok: false, Message: "failed to revoke api key"}, nil
+	}
+	s.closeClients(func(c *clientSession) bool { return c.apiKeyID == keyID }, status.Error(codes.Unauthenticated, "api key revoked"))
+
+	log.Printf("API key %d revoked by %s", keyID, caller)
+	return &pb.RevokeApiKeyResponse{Ok: true, Message: "api key revoked"}, nil
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Thời hạn của một session sau khi login
+const sessionTTL = 7 * 24 * time.Hour
+
+// errSessionRevoked is the status a ChatStream ends with when its session is revoked
+var errSessionRevoked = status.Error(codes.Unauthenticated, "session revoked")
+
+// Các RPC không cần session token
+var publicMethods = map[string]bool{
+	pb.ChatService_Register_FullMethodName:      true,
+	pb.ChatService_Login_FullMethodName:         true,
+	pb.ChatService_ResetPassword_FullMethodName: true,
+}
+
+// Scope mà API key cần có để gọi từng RPC.
+// RPC không có trong map chỉ dùng được với session token.
+var methodScopes = map[string]string{
+	pb.ChatService_ListUsers_FullMethodName:              scopeRead,
+	pb.ChatService_SearchUsers_FullMethodName:            scopeRead,
+	pb.ChatService_GetUserGroups_FullMethodName:          scopeRead,
+	pb.ChatService_ChatStream_FullMethodName:             scopeChat,
+	pb.ChatService_CreateGroup_FullMethodName:            scopeGroups,
+	pb.ChatService_JoinGroup_FullMethodName:              scopeGroups,
+	pb.ChatService_PromoteMember_FullMethodName:          scopeGroups,
+	pb.ChatService_DemoteMember_FullMethodName:           scopeGroups,
+	pb.ChatService_TransferOwnership_FullMethodName:      scopeGroups,
+	pb.ChatService_SetGroupVisibility_FullMethodName:     scopeGroups,
+	pb.ChatService_InviteToGroup_FullMethodName:          scopeGroups,
+	pb.ChatService_ListInvitations_FullMethodName:        scopeGroups,
+	pb.ChatService_RespondInvitation_FullMethodName:      scopeGroups,
+	pb.ChatService_ListJoinRequests_FullMethodName:       scopeGroups,
+	pb.ChatService_ReviewJoinRequest_FullMethodName:      scopeGroups,
+	pb.ChatService_CreateInvite_FullMethodName:           scopeGroups,
+	pb.ChatService_RedeemInvite_FullMethodName:           scopeGroups,
+	pb.ChatService_ListInvites_FullMethodName:            scopeGroups,
+	pb.ChatService_RevokeInvite_FullMethodName:           scopeGroups,
+	pb.ChatService_LeaveGroup_FullMethodName:             scopeGroups,
+	pb.ChatService_RemoveMember_FullMethodName:           scopeGroups,
+	pb.ChatService_BanMember_FullMethodName:              scopeGroups,
+	pb.ChatService_UnbanMember_FullMethodName:            scopeGroups,
+	pb.ChatService_ListBans_FullMethodName:               scopeGroups,
+	pb.ChatService_UpdateGroup_FullMethodName:            scopeGroups,
+	pb.ChatService_ListPublicGroups_FullMethodName:       scopeRead,
+	pb.ChatService_SearchGroups_FullMethodName:           scopeRead,
+	pb.ChatService_ListWorkspaces_FullMethodName:         scopeRead,
+	pb.ChatService_GetHistory_FullMethodName:             scopeRead,
+	pb.ChatService_GetThread_FullMethodName:              scopeRead,
+	pb.ChatService_GetMessageEdits_FullMethodName:        scopeRead,
+	pb.ChatService_EditMessage_FullMethodName:            scopeChat,
+	pb.ChatService_AddReaction_FullMethodName:            scopeChat,
+	pb.ChatService_RemoveReaction_FullMethodName:         scopeChat,
+	pb.ChatService_MarkRead_FullMethodName:               scopeChat,
+	pb.ChatService_MuteConversation_FullMethodName:       scopeChat,
+	pb.ChatService_ListConversations_FullMethodName:      scopeRead,
+	pb.ChatService_UploadFile_FullMethodName:             scopeChat,
+	pb.ChatService_DownloadFile_FullMethodName:           scopeRead,
+	pb.ChatService_ScheduleMessage_FullMethodName:        scopeChat,
+	pb.ChatService_ListScheduledMessages_FullMethodName:  scopeRead,
+	pb.ChatService_CancelScheduledMessage_FullMethodName: scopeChat,
+	pb.ChatService_SetDisappearing_FullMethodName:        scopeChat,
+	pb.ChatService_GetDisappearing_FullMethodName:        scopeRead,
+	pb.ChatService_PinMessage_FullMethodName:             scopeChat,
+	pb.ChatService_UnpinMessage_FullMethodName:           scopeChat,
+	pb.ChatService_ListPins_FullMethodName:               scopeRead,
+	pb.ChatService_ListMentions_FullMethodName:           scopeRead,
+	pb.ChatService_SearchMessages_FullMethodName:         scopeRead,
+	pb.ChatService_DeleteMessage_FullMethodName:          scopeChat,
+}
+
+// authInfo is attached to the request context by the auth interceptors.
+// Exactly one of sessionID and apiKeyID is set.
+type authInfo struct {
+	username  string
+	role      string
+	sessionID uint
+	apiKeyID  uint
+}
+
+type authContextKey struct{}
+
+func authFromContext(ctx context.Context) *authInfo {
+	auth, _ := ctx.Value(authContextKey{}).(*authInfo)
+	return auth
+}
+
+// callerName returns the authenticated username, or "" for public methods
+func callerName(ctx context.Context) string {
+	if auth := authFromContext(ctx); auth != nil {
+		return auth.username
+	}
+	return ""
+}
+
+// authenticate resolves the "x-api-key" or "authorization: Bearer <token>" metadata
+// to the calling user and rejects disabled accounts
+func (s *chatServer) authenticate(ctx context.Context, method string) (*authInfo, error) {
+	auth, err := s.authenticateCredentials(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := db.GetUserByUsername(auth.username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "account no longer exists")
+		}
+		log.Printf("Error loading user %s: %v", auth.username, err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	if user.DisabledAt != nil {
+		return nil, status.Error(codes.PermissionDenied, "account disabled")
+	}
+
+	auth.role = user.Role
+	return auth, nil
+}
+
+func (s *chatServer) authenticateCredentials(ctx context.Context, method string) (*authInfo, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return s.authenticateAPIKey(keys[0], method)
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing session token")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization header")
+	}
+
+	sess, err := db.GetActiveSession(token)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidSession) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		log.Printf("Error looking up session: %v", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+
+	return &authInfo{username: sess.Username, sessionID: sess.ID}, nil
+}
+
+func (s *chatServer) authenticateAPIKey(key, method string) (*authInfo, error) {
+	apiKey, err := db.GetActiveAPIKey(key)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidAPIKey) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		log.Printf("Error looking up api key: %v", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+
+	required, ok := methodScopes[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method requires a login session")
+	}
+	if !slices.Contains(apiKey.ScopeList(), required) {
+		return nil, status.Errorf(codes.PermissionDenied, "api key lacks scope %q", required)
+	}
+
+	return &authInfo{username: apiKey.Username, apiKeyID: apiKey.ID}, nil
+}
+
+func (s *chatServer) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	auth, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, authContextKey{}, auth), req)
+}
+
+// authenticatedStream overrides Context so handlers can read the authInfo
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *authenticatedStream) Context() context.Context {
+	return w.ctx
+}
+
+func (s *chatServer) streamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	auth, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{
+		ServerStream: ss,
+		ctx:          context.WithValue(ss.Context(), authContextKey{}, auth),
+	})
+}
+
+// closeSessions ends the open ChatStream of each given session with reason
+func (s *chatServer) closeSessions(ids []uint, reason error) {
+	if len(ids) == 0 {
+		return
+	}
+	revoked := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		revoked[id] = true
+	}
+	s.closeClients(func(c *clientSession) bool { return c.sessionID != 0 && revoked[c.sessionID] }, reason)
+}
+
+// closeClients ends every open ChatStream matching match with reason
+func (s *chatServer) closeClients(match func(c *clientSession) bool, reason error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, c := range s.clients {
+		if match(c) {
+			c.terminate(reason)
+		}
+	}
+}
+
+// Logout - Hủy session hiện tại
+func (s *chatServer) Logout(ctx context.Context, _ *pb.Empty) (*pb.LogoutResponse, error) {
+	auth := authFromContext(ctx)
+	if err := db.RevokeSession(auth.username, auth.sessionID); err != nil {
+		log.Printf("Error revoking session %d of %s: %v", auth.sessionID, auth.username, err)
+		return &pb.LogoutResponse{Ok: false, Message: "failed to log out"}, nil
+	}
+	s.closeSessions([]uint{auth.sessionID}, status.Error(codes.Unauthenticated, "logged out"))
+
+	log.Printf("User logged out: %s (session %d)", auth.username, auth.sessionID)
+	return &pb.LogoutResponse{Ok: true, Message: "logged out"}, nil
+}
+
+// ListSessions - Liệt kê các session còn hiệu lực của user
+func (s *chatServer) ListSessions(ctx context.Context, _ *pb.Empty) (*pb.ListSessionsResponse, error) {
+	auth := authFromContext(ctx)
+	resp := &pb.ListSessionsResponse{}
+
+	sessions, err := db.ListSessions(auth.username)
+	if err != nil {
+		log.Printf("Error listing sessions for %s: %v", auth.username, err)
+		return resp, nil
+	}
+
+	s.mu.RLock()
+	connected := make(map[uint]bool)
+	for _, c := range s.clients {
+		if c.username == auth.username {
+			connected[c.sessionID] = true
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, sess := range sessions {
+		resp.Sessions = append(resp.Sessions, &pb.SessionInfo{
+			Id:        int64(sess.ID),
+			CreatedAt: sess.CreatedAt.Unix(),
+			ExpiresAt: sess.ExpiresAt.Unix(),
+			Current:   sess.ID == auth.sessionID,
+			Connected: connected[sess.ID],
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession - Hủy một session của chính user và ngắt ChatStream đang dùng nó
+func (s *chatServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	auth := authFromContext(ctx)
+	sessionID := uint(req.SessionId)
+
+	if err := db.RevokeSession(auth.username, sessionID); err != nil {
+		if errors.Is(err, database.ErrInvalidSession) {
+			return &pb.RevokeSessionResponse{Ok: false, Message: "session not found"}, nil
+		}
+		log.Printf("Error revoking session %d of %s: %v", sessionID, auth.username, err)
+		return &pb.RevokeSessionResponse{Ok: false, Message: "failed to revoke session"}, nil
+	}
+	s.closeSessions([]uint{sessionID}, errSessionRevoked)
+
+	log.Printf("Session %d of %s revoked", sessionID, auth.username)
+	return &pb.RevokeSessionResponse{Ok: true, Message: "session revoked"}, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	pb "chat-grpc/proto"
+)
+
+const (
+	defaultConversationLimit = 20
+	maxConversationLimit     = 100
+	previewLength            = 80
+)
+
+// messagePreview cắt nội dung tin nhắn cuối để hiện trong inbox
+func messagePreview(text string, deleted bool) string {
+	if deleted {
+		return "(message deleted)"
+	}
+	if utf8.RuneCountInString(text) <= previewLength {
+		return text
+	}
+	return string([]rune(text)[:previewLength-1]) + "…"
+}
+
+// ListConversations - Inbox: các nhóm và chat riêng của user, hoạt động gần nhất trước,
+// kèm preview tin nhắn cuối, số tin chưa đọc và trạng thái mute
+func (s *chatServer) ListConversations(ctx context.Context, req *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
+	caller := callerName(ctx)
+	resp := &pb.ListConversationsResponse{}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultConversationLimit
+	}
+	if limit > maxConversationLimit {
+		limit = maxConversationLimit
+	}
+	offset := max(int(req.Offset), 0)
+
+	// Lấy thêm một dòng để biết còn trang sau không
+	conversations, err := db.ListConversations(caller, offset, limit+1)
+	if err != nil {
+		log.Printf("Error listing conversations of %s: %v", caller, err)
+		return resp, nil
+	}
+	if len(conversations) > limit {
+		conversations = conversations[:limit]
+		resp.NextOffset = int32(offset + limit)
+	}
+
+	for _, c := range conversations {
+		info := &pb.ConversationInfo{
+			ChatType:       c.ChatType,
+			Target:         c.Target,
+			GroupId:        int64(c.GroupID),
+			UnreadCount:    int32(c.Unread),
+			LastReadId:     int64(c.LastReadID),
+			LastActivityAt: c.Activity.Unix(),
+			Muted:          c.Muted,
+		}
+		if c.LastID != nil {
+			info.LastMessageId = int64(*c.LastID)
+			info.LastMessageFrom = c.LastFrom
+			info.LastMessagePreview = messagePreview(c.LastText, c.LastDeleted)
+		}
+		if c.MutedUntil != nil {
+			info.MutedUntil = c.MutedUntil.Unix()
+		}
+		resp.Conversations = append(resp.Conversations, info)
+	}
+	return resp, nil
+}
+
+// MuteConversation - Tắt / bật thông báo của một conversation, có thể kèm thời hạn
+func (s *chatServer) MuteConversation(ctx context.Context, req *pb.MuteRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+	group, peer, err := s.resolveConversation(caller, req.ChatType, req.Target, req.GroupId)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	var groupID uint
+	name := "@" + peer
+	if group != nil {
+		groupID, name = group.ID, group.Name
+	}
+
+	if !req.Mute {
+		removed, err := db.UnmuteConversation(caller, groupID, peer)
+		if err != nil {
+			log.Printf("Error unmuting %s for %s: %v", name, caller, err)
+			return &pb.MessageActionResponse{Ok: false, Message: "failed to unmute"}, nil
+		}
+		if !removed {
+			return &pb.MessageActionResponse{Ok: false, Message: name + " is not muted"}, nil
+		}
+		return &pb.MessageActionResponse{Ok: true, Message: name + " unmuted"}, nil
+	}
+
+	if req.DurationSeconds < 0 {
+		return &pb.MessageActionResponse{Ok: false, Message: "invalid duration"}, nil
+	}
+	var until *time.Time
+	msg := name + " muted"
+	if req.DurationSeconds > 0 {
+		t := time.Now().Add(time.Duration(req.DurationSeconds) * time.Second)
+		until = &t
+		msg = fmt.Sprintf("%s muted until %s", name, t.Format("2006-01-02 15:04"))
+	}
+	if err := db.MuteConversation(caller, groupID, peer, until); err != nil {
+		log.Printf("Error muting %s for %s: %v", name, caller, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to mute"}, nil
+	}
+	return &pb.MessageActionResponse{Ok: true, Message: msg}, nil
+}
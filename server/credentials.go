@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Username: 3-32 ký tự, bắt đầu bằng chữ hoặc số
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{2,31}$`)
+
+// Reserved names are used by the server itself or by chat syntax
+var reservedUsernames = map[string]bool{
+	"server": true,
+	"system": true,
+	"admin":  true,
+	"all":    true,
+	"here":   true,
+}
+
+// credentialPolicy holds the username and password rules enforced by the server
+type credentialPolicy struct {
+	MinPasswordLength int
+	MaxPasswordLength int
+	breached          map[string]struct{}
+}
+
+func defaultCredentialPolicy() *credentialPolicy {
+	return &credentialPolicy{
+		MinPasswordLength: 8,
+		MaxPasswordLength: 72, // bcrypt chỉ dùng 72 byte đầu
+		breached:          make(map[string]struct{}),
+	}
+}
+
+// loadBreachList reads a local file of known-breached passwords, one per line
+func (p *credentialPolicy) loadBreachList(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.breached[line] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+func (p *credentialPolicy) validateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("username must be 3-32 characters of letters, digits, '_', '.' or '-' and start with a letter or digit")
+	}
+	if reservedUsernames[strings.ToLower(username)] {
+		return fmt.Errorf("username %q is reserved", username)
+	}
+	return nil
+}
+
+func (p *credentialPolicy) validatePassword(username, password string) error {
+	if len(password) < p.MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinPasswordLength)
+	}
+	if len(password) > p.MaxPasswordLength {
+		return fmt.Errorf("password must be at most %d characters", p.MaxPasswordLength)
+	}
+	if strings.EqualFold(password, username) {
+		return fmt.Errorf("password must not match the username")
+	}
+	if _, ok := p.breached[password]; ok {
+		return fmt.Errorf("password appears in a list of breached passwords")
+	}
+	return nil
+}
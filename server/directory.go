@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	defaultDirectoryLimit = 20
+	maxDirectoryLimit     = 100
+)
+
+// directoryPage chuẩn hóa limit / offset của group directory
+func directoryPage(limit, offset int32) (int, int) {
+	l := int(limit)
+	if l <= 0 {
+		l = defaultDirectoryLimit
+	}
+	if l > maxDirectoryLimit {
+		l = maxDirectoryLimit
+	}
+	o := int(offset)
+	if o < 0 {
+		o = 0
+	}
+	return l, o
+}
+
+// toDirectoryResponse builds a page; groups holds up to limit+1 rows so the
+// extra row tells whether another page exists
+func toDirectoryResponse(groups []database.GroupSummary, offset, limit int) *pb.GroupDirectoryResponse {
+	resp := &pb.GroupDirectoryResponse{}
+	if len(groups) > limit {
+		groups = groups[:limit]
+		resp.NextOffset = int32(offset + limit)
+	}
+	for _, g := range groups {
+		entry := &pb.GroupDirectoryEntry{
+			Id:             int64(g.ID),
+			Name:           g.Name,
+			Kind:           g.Kind,
+			Workspace:      g.WorkspaceSlug,
+			DisplayName:    g.DisplayName,
+			Topic:          g.Topic,
+			Description:    g.Description,
+			MemberCount:    int32(g.MemberCount),
+			RecentMessages: int32(g.RecentMessages),
+		}
+		if entry.DisplayName == "" {
+			entry.DisplayName = g.Name
+		}
+		if g.LastActivity != nil {
+			entry.LastActivityAt = g.LastActivity.Unix()
+		}
+		resp.Groups = append(resp.Groups, entry)
+	}
+	return resp
+}
+
+// ListPublicGroups - Danh sách group public, group hoạt động nhiều nhất trước
+func (s *chatServer) ListPublicGroups(ctx context.Context, req *pb.ListPublicGroupsRequest) (*pb.GroupDirectoryResponse, error) {
+	limit, offset := directoryPage(req.Limit, req.Offset)
+
+	// Chỉ liệt kê group trong các workspace của caller
+	scope, err := workspaceScope(callerName(ctx), req.Workspace)
+	if err != nil {
+		return &pb.GroupDirectoryResponse{}, nil
+	}
+
+	groups, err := db.ListPublicGroups(scope, offset, limit+1)
+	if err != nil {
+		log.Printf("Error listing public groups: %v", err)
+		return &pb.GroupDirectoryResponse{}, nil
+	}
+	return toDirectoryResponse(groups, offset, limit), nil
+}
+
+// SearchGroups - Tìm group public với fuzzy search trên name / display name / description
+func (s *chatServer) SearchGroups(ctx context.Context, req *pb.SearchGroupsRequest) (*pb.GroupDirectoryResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return s.ListPublicGroups(ctx, &pb.ListPublicGroupsRequest{Limit: req.Limit, Offset: req.Offset, Workspace: req.Workspace})
+	}
+	limit, offset := directoryPage(req.Limit, req.Offset)
+
+	scope, err := workspaceScope(callerName(ctx), req.Workspace)
+	if err != nil {
+		return &pb.GroupDirectoryResponse{}, nil
+	}
+
+	groups, err := db.SearchGroups(query, scope, offset, limit+1)
+	if err != nil {
+		log.Printf("Error searching groups for query '%s': %v", query, err)
+		return &pb.GroupDirectoryResponse{}, nil
+	}
+
+	log.Printf("Group search '%s' returned %d groups", query, min(len(groups), limit))
+	return toDirectoryResponse(groups, offset, limit), nil
+}
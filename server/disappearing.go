@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	minDisappearTTL     = 10 * time.Second
+	maxDisappearTTL     = 90 * 24 * time.Hour
+	expirySweepInterval = 10 * time.Second
+	expirySweepBatch    = 200
+)
+
+// describeTTL renders a disappearing setting, e.g. "1h0m0s after sent"
+func describeTTL(ttlSeconds int64, mode string) string {
+	return fmt.Sprintf("%s after %s", time.Duration(ttlSeconds)*time.Second, mode)
+}
+
+// disappearingConversation resolves the conversation of a request and its setting key
+func (s *chatServer) disappearingConversation(caller, chatType, target string, groupID int64) (*database.Group, string, string, error) {
+	group, peer, err := s.resolveConversation(caller, chatType, target, groupID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if group != nil {
+		return group, "", database.PinConversation(group.ID, "", ""), nil
+	}
+	return nil, peer, database.PinConversation(0, caller, peer), nil
+}
+
+// GetDisappearing - Xem setting tin nhắn tự hủy của conversation
+func (s *chatServer) GetDisappearing(ctx context.Context, req *pb.DisappearingRequest) (*pb.DisappearingResponse, error) {
+	caller := callerName(ctx)
+	_, _, key, err := s.disappearingConversation(caller, req.ChatType, req.Target, req.GroupId)
+	if err != nil {
+		return &pb.DisappearingResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	setting, err := db.GetConversationTTL(key)
+	if err != nil {
+		log.Printf("Error loading disappearing setting of %s: %v", key, err)
+		return &pb.DisappearingResponse{Ok: false, Message: "database error"}, nil
+	}
+	if setting == nil {
+		return &pb.DisappearingResponse{Ok: true, Message: "disappearing messages are off"}, nil
+	}
+	return &pb.DisappearingResponse{
+		Ok:         true,
+		Message:    "disappearing messages: " + describeTTL(setting.TTLSeconds, setting.Mode),
+		TtlSeconds: setting.TTLSeconds,
+		Mode:       setting.Mode,
+		SetBy:      setting.SetBy,
+		UpdatedAt:  setting.UpdatedAt.Unix(),
+	}, nil
+}
+
+// SetDisappearing - Bật / tắt tin nhắn tự hủy. Nhóm cần role admin, chat riêng thì cả hai người đều đổi được.
+// Chỉ áp dụng cho tin nhắn gửi sau khi đổi.
+func (s *chatServer) SetDisappearing(ctx context.Context, req *pb.SetDisappearingRequest) (*pb.DisappearingResponse, error) {
+	caller := callerName(ctx)
+	group, peer, key, err := s.disappearingConversation(caller, req.ChatType, req.Target, req.GroupId)
+	if err != nil {
+		return &pb.DisappearingResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	if ttl != 0 && (ttl < minDisappearTTL || ttl > maxDisappearTTL) {
+		return &pb.DisappearingResponse{Ok: false, Message: fmt.Sprintf("ttl must be between %s and %s", minDisappearTTL, maxDisappearTTL)}, nil
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = database.DisappearAfterSent
+	}
+	if mode != database.DisappearAfterSent && mode != database.DisappearAfterRead {
+		return &pb.DisappearingResponse{Ok: false, Message: "mode must be sent or read"}, nil
+	}
+
+	if group != nil {
+		if err := checkGroupRole(group, caller, database.GroupRoleAdmin); err != nil {
+			return &pb.DisappearingResponse{Ok: false, Message: "only group admins can change disappearing messages"}, nil
+		}
+	} else {
+		shared, err := db.SharesWorkspace(caller, peer)
+		if err != nil {
+			log.Printf("Error checking workspaces of %s and %s: %v", caller, peer, err)
+			return &pb.DisappearingResponse{Ok: false, Message: "database error"}, nil
+		}
+		if !shared {
+			return &pb.DisappearingResponse{Ok: false, Message: fmt.Sprintf("you do not share a workspace with %s", peer)}, nil
+		}
+	}
+
+	if err := db.SetConversationTTL(key, ttl, mode, caller); err != nil {
+		log.Printf("Error setting disappearing messages of %s: %v", key, err)
+		return &pb.DisappearingResponse{Ok: false, Message: "failed to change disappearing messages"}, nil
+	}
+
+	text := fmt.Sprintf("%s turned off disappearing messages", caller)
+	if ttl > 0 {
+		text = fmt.Sprintf("%s turned on disappearing messages: %s", caller, describeTTL(req.TtlSeconds, mode))
+	}
+	log.Printf("[%s] %s", key, text)
+	if group != nil {
+		s.broadcastSystem(group, text)
+	} else {
+		s.notify(caller, "notice", peer, text)
+		s.notify(peer, "notice", caller, text)
+	}
+
+	resp := &pb.DisappearingResponse{Ok: true, Message: text, SetBy: caller, UpdatedAt: time.Now().Unix()}
+	if ttl > 0 {
+		resp.TtlSeconds, resp.Mode = req.TtlSeconds, mode
+	}
+	return resp, nil
+}
+
+// runExpirySweeper xóa hẳn các message đã hết hạn và báo delete cho người đang online
+func (s *chatServer) runExpirySweeper() {
+	ticker := time.NewTicker(expirySweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		groups := make(map[uint]*database.Group)
+		for {
+			expired, err := db.DeleteExpiredMessages(time.Now(), expirySweepBatch)
+			if err != nil {
+				log.Printf("Error deleting expired messages: %v", err)
+				break
+			}
+			for i := range expired {
+				s.pushExpired(&expired[i], groups)
+			}
+			if len(expired) < expirySweepBatch {
+				break
+			}
+		}
+	}
+}
+
+// pushExpired gửi event delete của một message hết hạn; groups cache group theo ID trong một lượt quét
+func (s *chatServer) pushExpired(m *database.Message, groups map[uint]*database.Group) {
+	var group *database.Group
+	if m.GroupID != nil {
+		var ok bool
+		if group, ok = groups[*m.GroupID]; !ok {
+			g, err := db.GetGroupByID(*m.GroupID)
+			if err != nil {
+				log.Printf("Error loading group %d of expired message %d: %v", *m.GroupID, m.ID, err)
+			}
+			group, groups[*m.GroupID] = g, g
+		}
+		if group == nil {
+			return
+		}
+	}
+
+	ev := messageEvent(m, "delete", "system")
+	ev.Text, ev.Deleted, ev.Mentions = "", true, nil
+	s.pushMessageEvent(m, group, ev)
+}
+
+// setExpiry copies the expiry of a stored message onto the message being delivered
+func setExpiry(msg *pb.ChatMessage, saved *database.Message) {
+	msg.ExpireTtl, msg.ExpiresAt = saved.ExpireTTL, 0
+	if saved.ExpiresAt != nil {
+		msg.ExpiresAt = saved.ExpiresAt.Unix()
+	}
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+	"chat-grpc/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxUploadSize         = 10 << 20  // bytes per file
+	maxUploadChunk        = 256 << 10 // bytes per UploadFile message
+	downloadChunkSize     = 64 << 10
+	maxFilenameLength     = 255
+	maxMessageAttachments = 10
+	sniffLength           = 512 // bytes http.DetectContentType looks at
+
+	staleAttachmentAge      = 24 * time.Hour // uploads never sent are removed after this
+	attachmentSweepInterval = time.Hour
+	attachmentSweepBatch    = 500
+)
+
+// allowedContentTypes lists the file types that can be uploaded
+var allowedContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"application/zip": true,
+	"text/plain":      true,
+}
+
+// toAttachment converts a stored attachment
+func toAttachment(a *database.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:          int64(a.ID),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      a.SHA256,
+	}
+}
+
+// validateUpload kiểm tra thông tin file trước khi nhận dữ liệu
+func validateUpload(info *pb.UploadFileInfo) (filename, contentType string, err error) {
+	filename = strings.TrimSpace(filepath.Base(strings.ReplaceAll(info.Filename, "\\", "/")))
+	if filename == "" || filename == "." || filename == "/" {
+		return "", "", errors.New("filename is required")
+	}
+	if len(filename) > maxFilenameLength || !utf8.ValidString(filename) {
+		return "", "", errors.New("invalid filename")
+	}
+
+	contentType, _, err = mime.ParseMediaType(info.ContentType)
+	if err != nil || !allowedContentTypes[contentType] {
+		return "", "", fmt.Errorf("content type %q is not allowed", info.ContentType)
+	}
+
+	if info.Size <= 0 {
+		return "", "", errors.New("file is empty")
+	}
+	if info.Size > maxUploadSize {
+		return "", "", fmt.Errorf("file is larger than %d MiB", maxUploadSize>>20)
+	}
+	return filename, contentType, nil
+}
+
+// uploadReader đọc các chunk của UploadFile stream, kiểm tra giới hạn và
+// nội dung thật của file, đồng thời tính sha256
+type uploadReader struct {
+	stream      pb.ChatService_UploadFileServer
+	contentType string
+	remaining   int64
+	pending     []byte
+	sniff       []byte
+	sniffed     bool
+	hash        hash.Hash
+	err         error // lỗi do client, trả về trong response
+	recvErr     error // stream bị hủy
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.remaining == 0 {
+			// Client không được gửi thêm dữ liệu sau khi đủ size
+			if _, err := r.stream.Recv(); err != io.EOF {
+				return 0, r.fail(errors.New("more data than the declared size"))
+			}
+			return 0, io.EOF
+		}
+
+		req, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, r.fail(errors.New("upload ended before the declared size"))
+		}
+		if err != nil {
+			r.recvErr = err
+			return 0, err
+		}
+		chunk := req.GetChunk()
+		switch {
+		case chunk == nil:
+			return 0, r.fail(errors.New("expected a data chunk"))
+		case len(chunk) > maxUploadChunk:
+			return 0, r.fail(fmt.Errorf("chunk larger than %d KiB", maxUploadChunk>>10))
+		case int64(len(chunk)) > r.remaining:
+			return 0, r.fail(errors.New("more data than the declared size"))
+		}
+		r.remaining -= int64(len(chunk))
+		r.pending = chunk
+
+		if err := r.checkContent(); err != nil {
+			return 0, r.fail(err)
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.hash.Write(p[:n])
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// checkContent so kiểu file khai báo với nội dung thật khi đã có đủ byte đầu,
+// để không upload được ví dụ HTML dưới dạng image/png
+func (r *uploadReader) checkContent() error {
+	if r.sniffed {
+		return nil
+	}
+	r.sniff = append(r.sniff, r.pending[:min(len(r.pending), sniffLength-len(r.sniff))]...)
+	if len(r.sniff) < sniffLength && r.remaining > 0 {
+		return nil
+	}
+	r.sniffed = true
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(r.sniff))
+	if detected != r.contentType {
+		return fmt.Errorf("file content does not match content type %s", r.contentType)
+	}
+	return nil
+}
+
+func (r *uploadReader) fail(err error) error {
+	r.err = err
+	return err
+}
+
+// UploadFile - Nhận file theo chunk (client streaming) và lưu vào blob store.
+// Message đầu tiên mang info, các message sau mang dữ liệu.
+func (s *chatServer) UploadFile(stream pb.ChatService_UploadFileServer) error {
+	ctx := stream.Context()
+	caller := callerName(ctx)
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: "the first message must carry the file info"})
+	}
+	filename, contentType, err := validateUpload(info)
+	if err != nil {
+		return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: err.Error()})
+	}
+
+	key, err := storage.NewKey()
+	if err != nil {
+		log.Printf("Error generating blob key: %v", err)
+		return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: "failed to store file"})
+	}
+
+	reader := &uploadReader{stream: stream, contentType: contentType, remaining: info.Size, hash: sha256.New()}
+	if err := blobs.Put(ctx, key, reader, info.Size, contentType); err != nil {
+		switch {
+		case reader.recvErr != nil:
+			return reader.recvErr
+		case reader.err != nil:
+			return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: reader.err.Error()})
+		}
+		log.Printf("Error storing upload %q of %s: %v", filename, caller, err)
+		return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: "failed to store file"})
+	}
+
+	attachment := &database.Attachment{
+		StorageKey:  key,
+		Uploader:    caller,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        info.Size,
+		SHA256:      hex.EncodeToString(reader.hash.Sum(nil)),
+	}
+	if err := db.CreateAttachment(attachment); err != nil {
+		log.Printf("Error recording upload %q of %s: %v", filename, caller, err)
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("Error deleting blob %s: %v", key, err)
+		}
+		return stream.SendAndClose(&pb.UploadFileResponse{Ok: false, Message: "failed to store file"})
+	}
+
+	log.Printf("%s uploaded %s (%s, %d bytes) as attachment %d", caller, filename, contentType, info.Size, attachment.ID)
+	return stream.SendAndClose(&pb.UploadFileResponse{Ok: true, Message: "file uploaded", Attachment: toAttachment(attachment)})
+}
+
+// attachmentForReading loads an attachment the caller may download: the
+// uploader always can, anyone else needs access to the message it was sent with
+func (s *chatServer) attachmentForReading(id int64, caller string) (*database.Attachment, error) {
+	a, err := db.GetAttachment(uint(id))
+	if err != nil {
+		if errors.Is(err, database.ErrAttachmentNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		log.Printf("Error loading attachment %d: %v", id, err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	if a.Uploader == caller {
+		return a, nil
+	}
+	if a.MessageID == nil {
+		return nil, status.Error(codes.NotFound, database.ErrAttachmentNotFound.Error())
+	}
+	if _, _, err := s.loadMessage(int64(*a.MessageID), caller); err != nil {
+		return nil, status.Error(codes.NotFound, database.ErrAttachmentNotFound.Error())
+	}
+	return a, nil
+}
+
+// DownloadFile - Gửi file theo chunk (server streaming); chunk đầu mang info
+func (s *chatServer) DownloadFile(req *pb.DownloadFileRequest, stream pb.ChatService_DownloadFileServer) error {
+	ctx := stream.Context()
+	caller := callerName(ctx)
+
+	a, err := s.attachmentForReading(req.AttachmentId, caller)
+	if err != nil {
+		return err
+	}
+	blob, err := blobs.Get(ctx, a.StorageKey)
+	if err != nil {
+		log.Printf("Error opening blob of attachment %d: %v", a.ID, err)
+		if errors.Is(err, storage.ErrNotFound) {
+			return status.Error(codes.NotFound, "file contents are missing")
+		}
+		return status.Error(codes.Internal, "failed to read file")
+	}
+	defer blob.Close()
+
+	buf := make([]byte, downloadChunkSize)
+	info := toAttachment(a)
+	for {
+		n, err := io.ReadFull(blob, buf)
+		if n > 0 {
+			chunk := &pb.FileChunk{Info: info, Data: buf[:n]}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			info = nil
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Error reading blob of attachment %d: %v", a.ID, err)
+			return status.Error(codes.Internal, "failed to read file")
+		}
+	}
+}
+
+// pendingAttachments kiểm tra các file gửi kèm message: phải do người gửi
+// upload và chưa gửi kèm message nào
+func pendingAttachments(msg *pb.ChatMessage) ([]database.Attachment, error) {
+	if len(msg.Attachments) == 0 {
+		return nil, nil
+	}
+	if len(msg.Attachments) > maxMessageAttachments {
+		return nil, fmt.Errorf("at most %d attachments per message", maxMessageAttachments)
+	}
+
+	ids := make([]uint, 0, len(msg.Attachments))
+	seen := make(map[int64]bool)
+	for _, a := range msg.Attachments {
+		if a.Id <= 0 || seen[a.Id] {
+			return nil, errors.New("invalid attachment")
+		}
+		seen[a.Id] = true
+		ids = append(ids, uint(a.Id))
+	}
+
+	attachments, err := db.GetPendingAttachments(ids, msg.From)
+	if err != nil {
+		log.Printf("Error loading attachments of %s: %v", msg.From, err)
+		return nil, errors.New("database error")
+	}
+	if len(attachments) != len(ids) {
+		return nil, errors.New("attachment not found or already sent")
+	}
+	return attachments, nil
+}
+
+// linkAttachments gắn các file vào message đã lưu và đưa metadata vào message gửi đi
+func linkAttachments(msg *pb.ChatMessage, saved *database.Message, attachments []database.Attachment) {
+	msg.Attachments = nil
+	if len(attachments) == 0 || saved == nil {
+		return
+	}
+
+	ids := make([]uint, len(attachments))
+	for i := range attachments {
+		ids[i] = attachments[i].ID
+	}
+	linked, err := db.AttachToMessage(ids, msg.From, saved.ID)
+	if err != nil {
+		log.Printf("Error attaching files to message %d: %v", saved.ID, err)
+		return
+	}
+	if linked != int64(len(ids)) {
+		log.Printf("Only %d of %d attachments linked to message %d", linked, len(ids), saved.ID)
+	}
+	for i := range attachments {
+		msg.Attachments = append(msg.Attachments, toAttachment(&attachments[i]))
+	}
+}
+
+// attachFiles fills in the attachments of stored messages
+func attachFiles(messages []*pb.ChatMessage) {
+	ids := make([]uint, 0, len(messages))
+	for _, m := range messages {
+		if m.Id != 0 && !m.Deleted {
+			ids = append(ids, uint(m.Id))
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	files, err := db.GetMessageAttachments(ids)
+	if err != nil {
+		log.Printf("Error loading attachments: %v", err)
+		return
+	}
+	for _, m := range messages {
+		for i := range files[uint(m.Id)] {
+			m.Attachments = append(m.Attachments, toAttachment(&files[uint(m.Id)][i]))
+		}
+	}
+}
+
+// sweepAttachments định kỳ xóa file upload mà không gửi, hoặc thuộc message đã bị xóa
+func sweepAttachments() {
+	ticker := time.NewTicker(attachmentSweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for {
+			keys, err := db.DeleteStaleAttachments(time.Now().Add(-staleAttachmentAge), attachmentSweepBatch)
+			if err != nil {
+				log.Printf("Error deleting stale attachments: %v", err)
+				break
+			}
+			for _, key := range keys {
+				if err := blobs.Delete(context.Background(), key); err != nil {
+					log.Printf("Error deleting blob %s: %v", key, err)
+				}
+			}
+			if len(keys) > 0 {
+				log.Printf("Deleted %d stale attachments", len(keys))
+			}
+			if len(keys) < attachmentSweepBatch {
+				break
+			}
+		}
+	}
+}
+
+// openBlobStore mở blob store theo flag -blob-store
+func openBlobStore(kind, dir, bucket string) (storage.BlobStore, error) {
+	switch kind {
+	case "local":
+		return storage.NewLocalStore(dir)
+	case "s3-local":
+		// S3 store trên bucket giả lập bằng thư mục local
+		return storage.NewS3Store(storage.NewLocalBucket(dir), bucket, "attachments"), nil
+	}
+	return nil, fmt.Errorf("unknown blob store %q (local or s3-local)", kind)
+}
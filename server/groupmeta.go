@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// Giới hạn độ dài metadata của group
+const (
+	maxGroupDisplayName = 100
+	maxGroupTopic       = 255
+	maxGroupDescription = 2000
+)
+
+// Group names are handles used in commands, so they cannot contain spaces
+var groupNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{1,99}$`)
+
+func validateGroupName(name string) error {
+	if !groupNamePattern.MatchString(name) {
+		return errors.New("group name must be 2-100 characters: letters, digits, '_', '.', '-'")
+	}
+	return nil
+}
+
+// toGroupInfo builds the GroupInfo of a group as seen by username
+func toGroupInfo(group *database.Group, members []database.GroupMember, username string) *pb.GroupInfo {
+	displayName := group.DisplayName
+	if displayName == "" {
+		displayName = group.Name
+	}
+	info := &pb.GroupInfo{
+		Id:            int64(group.ID),
+		Name:          group.Name,
+		DisplayName:   displayName,
+		Topic:         group.Topic,
+		Description:   group.Description,
+		Kind:          group.Kind,
+		Visibility:    group.Visibility,
+		PostPolicy:    group.PostPolicy,
+		InvitePolicy:  group.InvitePolicy,
+		MentionPolicy: group.MentionPolicy,
+	}
+	for _, m := range members {
+		info.Members = append(info.Members, m.Username)
+		switch m.Role {
+		case database.GroupRoleOwner:
+			info.Owner = m.Username
+		case database.GroupRoleAdmin:
+			info.Admins = append(info.Admins, m.Username)
+		}
+		if m.Username == username {
+			info.MyRole = m.Role
+		}
+	}
+	return info
+}
+
+// UpdateGroup - Sửa metadata của group. Admin sửa display name, topic, description;
+// chỉ owner đổi name, kind và settings (post / invite / mention policy).
+func (s *chatServer) UpdateGroup(ctx context.Context, req *pb.UpdateGroupRequest) (*pb.UpdateGroupResponse, error) {
+	caller := callerName(ctx)
+
+	group, err := loadGroup(req.GroupId, req.GroupName, caller)
+	if err != nil {
+		return &pb.UpdateGroupResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	minRole := database.GroupRoleAdmin
+	if req.Name != nil || req.PostPolicy != nil || req.InvitePolicy != nil || req.MentionPolicy != nil || req.Kind != nil {
+		minRole = database.GroupRoleOwner
+	}
+	if err := checkGroupRole(group, caller, minRole); err != nil {
+		return &pb.UpdateGroupResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	changes := make(map[string]interface{})
+	var notes []string
+	if req.Name != nil && *req.Name != group.Name {
+		if err := validateGroupName(*req.Name); err != nil {
+			return &pb.UpdateGroupResponse{Ok: false, Message: err.Error()}, nil
+		}
+		var workspaceID uint
+		if group.WorkspaceID != nil {
+			workspaceID = *group.WorkspaceID
+		}
+		exists, err := db.GroupExists(workspaceID, *req.Name)
+		if err != nil {
+			log.Printf("Error checking group existence: %v", err)
+			return &pb.UpdateGroupResponse{Ok: false, Message: "database error"}, nil
+		}
+		if exists {
+			return &pb.UpdateGroupResponse{Ok: false, Message: "group name already taken in this workspace"}, nil
+		}
+		changes["name"] = *req.Name
+		notes = append(notes, fmt.Sprintf("renamed the group to %s", *req.Name))
+	}
+	if req.DisplayName != nil {
+		name := strings.TrimSpace(*req.DisplayName)
+		if name == "" || len(name) > maxGroupDisplayName {
+			return &pb.UpdateGroupResponse{Ok: false, Message: fmt.Sprintf("display name must be 1-%d characters", maxGroupDisplayName)}, nil
+		}
+		changes["display_name"] = name
+		notes = append(notes, fmt.Sprintf("changed the display name to %q", name))
+	}
+	if req.Topic != nil {
+		if len(*req.Topic) > maxGroupTopic {
+			return &pb.UpdateGroupResponse{Ok: false, Message: fmt.Sprintf("topic must be at most %d characters", maxGroupTopic)}, nil
+		}
+		changes["topic"] = *req.Topic
+		if *req.Topic == "" {
+			notes = append(notes, "cleared the topic")
+		} else {
+			notes = append(notes, fmt.Sprintf("set the topic to %q", *req.Topic))
+		}
+	}
+	if req.Description != nil {
+		if len(*req.Description) > maxGroupDescription {
+			return &pb.UpdateGroupResponse{Ok: false, Message: fmt.Sprintf("description must be at most %d characters", maxGroupDescription)}, nil
+		}
+		changes["description"] = *req.Description
+		notes = append(notes, "updated the description")
+	}
+	if req.PostPolicy != nil {
+		if !database.ValidGroupPolicy(*req.PostPolicy) {
+			return &pb.UpdateGroupResponse{Ok: false, Message: "post policy must be members or admins"}, nil
+		}
+		changes["post_policy"] = *req.PostPolicy
+		notes = append(notes, fmt.Sprintf("allowed %s to post", *req.PostPolicy))
+	}
+	if req.InvitePolicy != nil {
+		if !database.ValidGroupPolicy(*req.InvitePolicy) {
+			return &pb.UpdateGroupResponse{Ok: false, Message: "invite policy must be members or admins"}, nil
+		}
+		changes["invite_policy"] = *req.InvitePolicy
+		notes = append(notes, fmt.Sprintf("allowed %s to invite", *req.InvitePolicy))
+	}
+	if req.MentionPolicy != nil {
+		if !database.ValidGroupPolicy(*req.MentionPolicy) {
+			return &pb.UpdateGroupResponse{Ok: false, Message: "mention policy must be members or admins"}, nil
+		}
+		changes["mention_policy"] = *req.MentionPolicy
+		notes = append(notes, fmt.Sprintf("allowed %s to mention @all", *req.MentionPolicy))
+	}
+	if req.Kind != nil && *req.Kind != group.Kind {
+		if !database.ValidGroupKind(*req.Kind) {
+			return &pb.UpdateGroupResponse{Ok: false, Message: "kind must be group or channel"}, nil
+		}
+		changes["kind"] = *req.Kind
+		notes = append(notes, fmt.Sprintf("turned the group into a %s", *req.Kind))
+	}
+	if len(changes) == 0 {
+		return &pb.UpdateGroupResponse{Ok: false, Message: "nothing to update"}, nil
+	}
+
+	updated, err := db.UpdateGroup(group.ID, changes)
+	if err != nil {
+		log.Printf("Error updating group %s: %v", group.Name, err)
+		return &pb.UpdateGroupResponse{Ok: false, Message: "failed to update group"}, nil
+	}
+	members, err := db.GetGroupMemberDetails(updated.ID)
+	if err != nil {
+		log.Printf("Error getting group members for %s: %v", updated.Name, err)
+	}
+
+	// Báo cho mọi member bằng system message
+	s.broadcastSystem(updated, fmt.Sprintf("%s %s", caller, strings.Join(notes, ", ")))
+
+	log.Printf("[GROUP %s] %s updated %d fields", updated.Name, caller, len(changes))
+	return &pb.UpdateGroupResponse{Ok: true, Message: "group updated", Group: toGroupInfo(updated, members, caller)}, nil
+}
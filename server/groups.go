@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"gorm.io/gorm"
+)
+
+// requireGroupRole loads a group (by ID, or by name when groupID is 0) and checks
+// username has at least role min in it
+func (s *chatServer) requireGroupRole(groupID int64, groupName, username, min string) (*database.Group, error) {
+	group, err := loadGroup(groupID, groupName, username)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGroupRole(group, username, min); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// checkGroupRole checks username has at least role min in an already loaded group
+func checkGroupRole(group *database.Group, username, min string) error {
+	role, err := db.GetGroupMemberRole(group.ID, username)
+	if err != nil && !errors.Is(err, database.ErrNotGroupMember) {
+		log.Printf("Error loading role of %s in %s: %v", username, group.Name, err)
+		return errors.New("database error")
+	}
+	if !database.GroupRoleAtLeast(role, min) {
+		return fmt.Errorf("requires group role %s", min)
+	}
+	return nil
+}
+
+// PromoteMember - Owner nâng member lên admin
+func (s *chatServer) PromoteMember(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleOwner)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	role, err := db.GetGroupMemberRole(group.ID, req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is not a member", req.Username)}, nil
+	}
+	if role != database.GroupRoleMember {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is already %s", req.Username, role)}, nil
+	}
+
+	if err := db.SetGroupMemberRole(group.ID, req.Username, database.GroupRoleAdmin); err != nil {
+		log.Printf("Error promoting %s in %s: %v", req.Username, group.Name, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to promote member"}, nil
+	}
+
+	log.Printf("[GROUP %s] %s promoted %s to admin", group.Name, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: "member promoted to admin"}, nil
+}
+
+// DemoteMember - Owner hạ admin xuống member
+func (s *chatServer) DemoteMember(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleOwner)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	role, err := db.GetGroupMemberRole(group.ID, req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is not a member", req.Username)}, nil
+	}
+	if role != database.GroupRoleAdmin {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is not an admin", req.Username)}, nil
+	}
+
+	if err := db.SetGroupMemberRole(group.ID, req.Username, database.GroupRoleMember); err != nil {
+		log.Printf("Error demoting %s in %s: %v", req.Username, group.Name, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to demote admin"}, nil
+	}
+
+	log.Printf("[GROUP %s] %s demoted %s to member", group.Name, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: "admin demoted to member"}, nil
+}
+
+// TransferOwnership - Chuyển quyền owner, owner cũ trở thành admin
+func (s *chatServer) TransferOwnership(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	if req.Username == caller {
+		return &pb.GroupActionResponse{Ok: false, Message: "you already own this group"}, nil
+	}
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleOwner)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.TransferGroupOwnership(group.ID, caller, req.Username); err != nil {
+		if errors.Is(err, database.ErrNotGroupMember) {
+			return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is not a member", req.Username)}, nil
+		}
+		log.Printf("Error transferring %s to %s: %v", group.Name, req.Username, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to transfer ownership"}, nil
+	}
+
+	log.Printf("[GROUP %s] ownership transferred from %s to %s", group.Name, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: "ownership transferred"}, nil
+}
+
+// loadGroup finds a group by ID, or by name among the workspaces of caller when id is 0
+func loadGroup(id int64, name, caller string) (*database.Group, error) {
+	var (
+		group *database.Group
+		err   error
+	)
+	if id != 0 {
+		group, err = db.GetGroupByID(uint(id))
+		name = fmt.Sprintf("#%d", id)
+	} else {
+		group, err = db.GetGroupByName(name, caller)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("group %s not found", name)
+		}
+		if errors.Is(err, database.ErrAmbiguousGroup) {
+			return nil, fmt.Errorf("%s exists in several workspaces, use workspace/%s", name, name)
+		}
+		log.Printf("Error loading group %s: %v", name, err)
+		return nil, errors.New("database error")
+	}
+	return group, nil
+}
+
+// groupForReading loads a group and checks username may read it.
+// Public groups are open to everyone but banned users, other groups only to members.
+func (s *chatServer) groupForReading(groupID int64, groupName, username string) (*database.Group, error) {
+	group, err := loadGroup(groupID, groupName, username)
+	if err != nil {
+		return nil, err
+	}
+	ban, err := db.GetActiveGroupBan(group.ID, username)
+	if err != nil {
+		log.Printf("Error checking ban of %s in %s: %v", username, group.Name, err)
+		return nil, errors.New("database error")
+	}
+	if ban != nil {
+		return nil, fmt.Errorf("you are banned from %s", group.Name)
+	}
+	if group.Visibility == database.GroupPublic {
+		// Group public chỉ mở cho người cùng workspace
+		if err := requireGroupWorkspace(group, username); err != nil {
+			return nil, err
+		}
+		return group, nil
+	}
+
+	member, err := db.IsGroupMember(group.ID, username)
+	if err != nil {
+		log.Printf("Error checking membership of %s in %s: %v", username, group.Name, err)
+		return nil, errors.New("database error")
+	}
+	if !member {
+		return nil, fmt.Errorf("group %s is %s and you are not a member", group.Name, group.Visibility)
+	}
+	return group, nil
+}
+
+// groupForPosting loads a group and checks username may post in it: the group
+// must be readable, posting needs membership even in public groups, and channels
+// or posting restricted to admins need the admin role.
+// Only new posts go through here; members of a channel can still react.
+func (s *chatServer) groupForPosting(groupID int64, groupName, username string) (*database.Group, error) {
+	group, err := s.groupForReading(groupID, groupName, username)
+	if err != nil {
+		return nil, err
+	}
+	// Ban xóa membership nên cũng chặn luôn việc post
+	member, err := db.IsGroupMember(group.ID, username)
+	if err != nil {
+		log.Printf("Error checking membership of %s in %s: %v", username, group.Name, err)
+		return nil, errors.New("database error")
+	}
+	if !member {
+		return nil, fmt.Errorf("you are not a member of %s", group.Name)
+	}
+	// Channel luôn chỉ cho admin post
+	if group.Kind != database.GroupKindChannel && group.PostPolicy != database.GroupPolicyAdmins {
+		return group, nil
+	}
+
+	role, err := db.GetGroupMemberRole(group.ID, username)
+	if err != nil && !errors.Is(err, database.ErrNotGroupMember) {
+		log.Printf("Error loading role of %s in %s: %v", username, group.Name, err)
+		return nil, errors.New("database error")
+	}
+	if !database.GroupRoleAtLeast(role, database.GroupRoleAdmin) {
+		if group.Kind == database.GroupKindChannel {
+			return nil, fmt.Errorf("%s is an announcement channel, only admins can post", group.Name)
+		}
+		return nil, fmt.Errorf("only admins can post in %s", group.Name)
+	}
+	return group, nil
+}
+
+// onlineSessions chụp danh sách user đang online (trừ skip)
+func (s *chatServer) onlineSessions(skip string) map[string]*clientSession {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	online := make(map[string]*clientSession, len(s.clients))
+	for name, c := range s.clients {
+		if name != skip {
+			online[name] = c
+		}
+	}
+	return online
+}
+
+// groupRecipients trả về các user đang online (trừ skip) và danh sách members cần xét.
+// Chỉ tra cứu phía nhỏ hơn: với group / channel lớn thì lọc các user đang online
+// theo membership thay vì load toàn bộ danh sách members.
+func (s *chatServer) groupRecipients(group *database.Group, skip string) (map[string]*clientSession, []string) {
+	online := s.onlineSessions(skip)
+	if len(online) == 0 {
+		return online, nil
+	}
+
+	memberCount, err := db.CountGroupMembers(group.ID)
+	if err != nil {
+		log.Printf("Error counting group members for %s: %v", group.Name, err)
+		return online, nil
+	}
+
+	var recipients []string
+	if int64(len(online)) < memberCount {
+		names := make([]string, 0, len(online))
+		for name := range online {
+			names = append(names, name)
+		}
+		recipients, err = db.FilterGroupMembers(group.ID, names)
+	} else {
+		var members []database.GroupMember
+		members, err = db.GetGroupMemberDetails(group.ID)
+		for _, m := range members {
+			recipients = append(recipients, m.Username)
+		}
+	}
+	if err != nil {
+		log.Printf("Error getting group members for %s: %v", group.Name, err)
+		return online, nil
+	}
+	return online, recipients
+}
+
+// fanoutGroup gửi msg tới các members đang online (trừ skip), trả về số người nhận
+func (s *chatServer) fanoutGroup(group *database.Group, msg *pb.ChatMessage, skip string) int {
+	online, recipients := s.groupRecipients(group, skip)
+
+	delivered := 0
+	for _, name := range recipients {
+		c, ok := online[name]
+		if !ok {
+			continue
+		}
+		select {
+		case c.send <- msg:
+			delivered++
+		default:
+			log.Printf("member buffer full in group %s", group.Name)
+		}
+	}
+	return delivered
+}
+
+// broadcastSystem lưu và gửi system message tới mọi member của group
+func (s *chatServer) broadcastSystem(group *database.Group, text string) {
+	if _, err := db.SaveGroupMessage(group, "system", "system", text); err != nil {
+		log.Printf("Error saving system message for %s: %v", group.Name, err)
+	}
+
+	msg := &pb.ChatMessage{
+		From:      "system",
+		To:        group.Name,
+		Type:      "system",
+		Text:      text,
+		Timestamp: time.Now().Unix(),
+		GroupId:   int64(group.ID),
+	}
+	delivered := s.fanoutGroup(group, msg, "")
+	log.Printf("[GROUP %s] system: %s (to %d members)", group.Name, text, delivered)
+}
+
+// notify gửi event của server tới user nếu đang online
+func (s *chatServer) notify(username, eventType, to, text string) {
+	s.mu.RLock()
+	c, ok := s.clients[username]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case c.send <- &pb.ChatMessage{From: "server", To: to, Type: eventType, Text: text, Timestamp: time.Now().Unix()}:
+	default:
+		log.Printf("user %s buffer full, dropping %s event", username, eventType)
+	}
+}
+
+// notifyGroupAdmins gửi notice tới owner và admins đang online
+func (s *chatServer) notifyGroupAdmins(group *database.Group, text string) {
+	admins, err := db.GetGroupAdmins(group.ID)
+	if err != nil {
+		log.Printf("Error loading admins of %s: %v", group.Name, err)
+		return
+	}
+	for _, admin := range admins {
+		s.notify(admin, "notice", group.Name, text)
+	}
+}
+
+// inviteUser tạo lời mời; ai được mời tùy theo invite policy của group
+func (s *chatServer) inviteUser(group *database.Group, inviter, invitee string) (string, error) {
+	if err := checkGroupRole(group, inviter, database.PolicyRole(group.InvitePolicy)); err != nil {
+		return "", err
+	}
+
+	if _, err := db.GetUserByUsername(invitee); err != nil {
+		return "", fmt.Errorf("user %s not found", invitee)
+	}
+	if err := requireGroupWorkspace(group, invitee); err != nil {
+		return "", err
+	}
+	member, err := db.IsGroupMember(group.ID, invitee)
+	if err != nil {
+		log.Printf("Error checking membership of %s in %s: %v", invitee, group.Name, err)
+		return "", errors.New("database error")
+	}
+	if member {
+		return "", fmt.Errorf("%s is already a member", invitee)
+	}
+	if ban, err := db.GetActiveGroupBan(group.ID, invitee); err != nil {
+		log.Printf("Error checking ban of %s in %s: %v", invitee, group.Name, err)
+		return "", errors.New("database error")
+	} else if ban != nil {
+		return "", fmt.Errorf("%s is banned from %s", invitee, group.Name)
+	}
+
+	inv, err := db.CreateGroupInvitation(group.ID, invitee, inviter)
+	if errors.Is(err, database.ErrRequestExists) {
+		return "", fmt.Errorf("%s already has a pending invitation", invitee)
+	}
+	if err != nil {
+		log.Printf("Error inviting %s to %s: %v", invitee, group.Name, err)
+		return "", errors.New("failed to invite user")
+	}
+
+	s.notify(invitee, "notice", group.Name, fmt.Sprintf("%s invited you to %s (invitation #%d)", inviter, group.Name, inv.ID))
+	log.Printf("[GROUP %s] %s invited %s", group.Name, inviter, invitee)
+	return fmt.Sprintf("invitation sent to %s", invitee), nil
+}
+
+// SetGroupVisibility - Owner đổi visibility của group
+func (s *chatServer) SetGroupVisibility(ctx context.Context, req *pb.SetGroupVisibilityRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	if !database.ValidGroupVisibility(req.Visibility) {
+		return &pb.GroupActionResponse{Ok: false, Message: "visibility must be public, private or invite_only"}, nil
+	}
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleOwner)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.SetGroupVisibility(group.ID, req.Visibility); err != nil {
+		log.Printf("Error setting visibility of %s: %v", group.Name, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to change visibility"}, nil
+	}
+
+	group.Visibility = req.Visibility
+	s.broadcastSystem(group, fmt.Sprintf("%s made the group %s", caller, req.Visibility))
+
+	log.Printf("[GROUP %s] %s set visibility to %s", group.Name, caller, req.Visibility)
+	return &pb.GroupActionResponse{Ok: true, Message: "group is now " + req.Visibility}, nil
+}
+
+// InviteToGroup - Mời user vào group
+func (s *chatServer) InviteToGroup(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupActionResponse, error) {
+	group, err := loadGroup(req.GroupId, req.GroupName, callerName(ctx))
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	msg, err := s.inviteUser(group, callerName(ctx), req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	return &pb.GroupActionResponse{Ok: true, Message: msg}, nil
+}
+
+// ListInvitations - Lấy các lời mời đang chờ của user
+func (s *chatServer) ListInvitations(ctx context.Context, _ *pb.Empty) (*pb.ListInvitationsResponse, error) {
+	resp := &pb.ListInvitationsResponse{}
+
+	invs, err := db.ListPendingInvitations(callerName(ctx))
+	if err != nil {
+		log.Printf("Error listing invitations: %v", err)
+		return resp, nil
+	}
+
+	for _, inv := range invs {
+		resp.Invitations = append(resp.Invitations, &pb.GroupInvitation{
+			Id:        int64(inv.ID),
+			GroupName: inv.Group.Name,
+			InvitedBy: inv.InvitedBy,
+			CreatedAt: inv.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// RespondInvitation - Accept hoặc decline lời mời
+func (s *chatServer) RespondInvitation(ctx context.Context, req *pb.RespondInvitationRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+
+	inv, err := db.RespondGroupInvitation(uint(req.InvitationId), caller, req.Accept)
+	if err != nil {
+		if errors.Is(err, database.ErrRequestNotFound) || errors.Is(err, database.ErrBannedFromGroup) {
+			return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error responding to invitation %d: %v", req.InvitationId, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to respond to invitation"}, nil
+	}
+
+	if !req.Accept {
+		log.Printf("[GROUP %s] %s declined invitation from %s", inv.Group.Name, caller, inv.InvitedBy)
+		return &pb.GroupActionResponse{Ok: true, Message: "invitation declined"}, nil
+	}
+	s.notify(inv.InvitedBy, "notice", inv.Group.Name, fmt.Sprintf("%s accepted your invitation to %s", caller, inv.Group.Name))
+	log.Printf("User %s joined group: %s (invited by %s)", caller, inv.Group.Name, inv.InvitedBy)
+	return &pb.GroupActionResponse{Ok: true, Message: "joined " + inv.Group.Name}, nil
+}
+
+// ListJoinRequests - Admin xem join requests đang chờ
+func (s *chatServer) ListJoinRequests(ctx context.Context, req *pb.GroupNameRequest) (*pb.ListJoinRequestsResponse, error) {
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, callerName(ctx), database.GroupRoleAdmin)
+	if err != nil {
+		return &pb.ListJoinRequestsResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	reqs, err := db.ListPendingJoinRequests(group.ID)
+	if err != nil {
+		log.Printf("Error listing join requests of %s: %v", group.Name, err)
+		return &pb.ListJoinRequestsResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.ListJoinRequestsResponse{Ok: true}
+	for _, jr := range reqs {
+		resp.Requests = append(resp.Requests, &pb.JoinRequestInfo{
+			Id:        int64(jr.ID),
+			GroupName: group.Name,
+			Username:  jr.Username,
+			CreatedAt: jr.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// ReviewJoinRequest - Admin duyệt hoặc từ chối join request
+func (s *chatServer) ReviewJoinRequest(ctx context.Context, req *pb.ReviewJoinRequestRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+
+	jr, err := db.GetPendingJoinRequest(uint(req.RequestId))
+	if err != nil {
+		if errors.Is(err, database.ErrRequestNotFound) {
+			return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error loading join request %d: %v", req.RequestId, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "database error"}, nil
+	}
+	if err := checkGroupRole(&jr.Group, caller, database.GroupRoleAdmin); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.ReviewJoinRequest(jr.ID, caller, req.Approve); err != nil {
+		if errors.Is(err, database.ErrRequestNotFound) || errors.Is(err, database.ErrBannedFromGroup) {
+			return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error reviewing join request %d: %v", jr.ID, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to review join request"}, nil
+	}
+
+	if !req.Approve {
+		s.notify(jr.Username, "notice", jr.Group.Name, fmt.Sprintf("your request to join %s was rejected", jr.Group.Name))
+		log.Printf("[GROUP %s] %s rejected join request of %s", jr.Group.Name, caller, jr.Username)
+		return &pb.GroupActionResponse{Ok: true, Message: "join request rejected"}, nil
+	}
+	s.notify(jr.Username, "notice", jr.Group.Name, fmt.Sprintf("your request to join %s was approved", jr.Group.Name))
+	log.Printf("User %s joined group: %s (approved by %s)", jr.Username, jr.Group.Name, caller)
+	return &pb.GroupActionResponse{Ok: true, Message: jr.Username + " added to " + jr.Group.Name}, nil
+}
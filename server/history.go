@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+)
+
+// GetHistory - Lấy lịch sử chat của group hoặc chat riêng với một user
+func (s *chatServer) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	caller := callerName(ctx)
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	var (
+		messages []database.Message
+		err      error
+	)
+	switch req.Type {
+	case "group":
+		// Group private chỉ members mới được đọc
+		group, gerr := s.groupForReading(req.GroupId, req.Target, caller)
+		if gerr != nil {
+			return &pb.GetHistoryResponse{Ok: false, Message: gerr.Error()}, nil
+		}
+		messages, err = db.GetGroupMessages(group.ID, limit)
+	case "private":
+		messages, err = db.GetPrivateMessages(caller, req.Target, limit)
+	default:
+		return &pb.GetHistoryResponse{Ok: false, Message: "type must be group or private"}, nil
+	}
+	if err != nil {
+		log.Printf("Error loading %s history of %s for %s: %v", req.Type, req.Target, caller, err)
+		return &pb.GetHistoryResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	// Database trả về mới nhất trước, đảo lại cho cũ nhất trước
+	resp := &pb.GetHistoryResponse{Ok: true}
+	for i := len(messages) - 1; i >= 0; i-- {
+		resp.Messages = append(resp.Messages, toChatMessage(&messages[i]))
+	}
+	attachReactions(resp.Messages, caller)
+	attachFiles(resp.Messages)
+	return resp, nil
+}
+
+// toChatMessage converts a stored message; tombstones keep their place with empty text
+func toChatMessage(m *database.Message) *pb.ChatMessage {
+	out := &pb.ChatMessage{
+		Id:        int64(m.ID),
+		From:      m.FromUser,
+		To:        m.ToTarget,
+		Type:      m.MessageType,
+		Text:      m.Text,
+		Timestamp: m.CreatedAt.Unix(),
+		Deleted:   m.Deleted(),
+	}
+	if m.GroupID != nil {
+		out.GroupId = int64(*m.GroupID)
+	}
+	if m.EditedAt != nil {
+		out.EditedAt = m.EditedAt.Unix()
+	}
+	if m.ReplyTo != nil {
+		out.ReplyTo = int64(*m.ReplyTo)
+	}
+	if m.ThreadRoot != nil {
+		out.ThreadRoot = int64(*m.ThreadRoot)
+	}
+	if len(m.Mentions) > 0 && !m.Deleted() {
+		out.Mentions = toMentions(m.Mentions)
+	}
+	if m.ExpireTTL > 0 {
+		out.ExpireTtl = m.ExpireTTL
+		if m.ExpiresAt != nil {
+			out.ExpiresAt = m.ExpiresAt.Unix()
+		}
+	}
+	if m.ReplyCount > 0 {
+		out.ReplyCount = int32(m.ReplyCount)
+		out.LastReplyBy = m.LastReplyBy
+		if m.LastReplyAt != nil {
+			out.LastReplyAt = m.LastReplyAt.Unix()
+		}
+	}
+	return out
+}
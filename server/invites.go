@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// maxInviteTTL giới hạn thời hạn của invite code
+const maxInviteTTL = 30 * 24 * time.Hour
+
+func toInviteCodeInfo(c *database.GroupInviteCode, groupName string, joined []string) *pb.InviteCodeInfo {
+	info := &pb.InviteCodeInfo{
+		Id:          int64(c.ID),
+		Code:        c.Code,
+		GroupName:   groupName,
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   c.CreatedAt.Unix(),
+		MaxUses:     int32(c.MaxUses),
+		Uses:        int32(c.Uses),
+		Revoked:     c.RevokedAt != nil,
+		JoinedUsers: joined,
+	}
+	if c.ExpiresAt != nil {
+		info.ExpiresAt = c.ExpiresAt.Unix()
+	}
+	return info
+}
+
+// CreateInvite - Admin tạo invite code có thời hạn / giới hạn lượt dùng
+func (s *chatServer) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteResponse, error) {
+	caller := callerName(ctx)
+	if req.ExpiresInSeconds < 0 || req.MaxUses < 0 {
+		return &pb.CreateInviteResponse{Ok: false, Message: "expiry and max uses must not be negative"}, nil
+	}
+	ttl := time.Duration(req.ExpiresInSeconds) * time.Second
+	if ttl > maxInviteTTL {
+		return &pb.CreateInviteResponse{Ok: false, Message: fmt.Sprintf("expiry must be at most %s", maxInviteTTL)}, nil
+	}
+
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleAdmin)
+	if err != nil {
+		return &pb.CreateInviteResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	invite, err := db.CreateInviteCode(group.ID, caller, ttl, int(req.MaxUses))
+	if err != nil {
+		log.Printf("Error creating invite for %s: %v", group.Name, err)
+		return &pb.CreateInviteResponse{Ok: false, Message: "failed to create invite"}, nil
+	}
+
+	log.Printf("[GROUP %s] %s created invite %d (ttl %s, max uses %d)", group.Name, caller, invite.ID, ttl, invite.MaxUses)
+	return &pb.CreateInviteResponse{Ok: true, Message: "invite created", Invite: toInviteCodeInfo(invite, group.Name, nil)}, nil
+}
+
+// RedeemInvite - Join group bằng invite code
+func (s *chatServer) RedeemInvite(ctx context.Context, req *pb.RedeemInviteRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+
+	invite, err := db.RedeemInviteCode(req.Code, caller)
+	if err != nil {
+		if errors.Is(err, database.ErrNotWorkspaceMember) {
+			return &pb.GroupActionResponse{Ok: false, Message: "you are not in the workspace of this group"}, nil
+		}
+		if errors.Is(err, database.ErrInvalidInviteCode) || errors.Is(err, database.ErrAlreadyMember) ||
+			errors.Is(err, database.ErrBannedFromGroup) {
+			return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error redeeming invite for %s: %v", caller, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to redeem invite"}, nil
+	}
+
+	log.Printf("User %s joined group: %s (invite %d by %s)", caller, invite.Group.Name, invite.ID, invite.CreatedBy)
+	return &pb.GroupActionResponse{Ok: true, Message: "joined " + invite.Group.Name}, nil
+}
+
+// ListInvites - Admin xem các invite code của group và ai đã join qua chúng
+func (s *chatServer) ListInvites(ctx context.Context, req *pb.GroupNameRequest) (*pb.ListInvitesResponse, error) {
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, callerName(ctx), database.GroupRoleAdmin)
+	if err != nil {
+		return &pb.ListInvitesResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	invites, err := db.ListInviteCodes(group.ID)
+	if err != nil {
+		log.Printf("Error listing invites of %s: %v", group.Name, err)
+		return &pb.ListInvitesResponse{Ok: false, Message: "database error"}, nil
+	}
+	joined, err := db.GetInviteCodeMembers(group.ID)
+	if err != nil {
+		log.Printf("Error loading invite members of %s: %v", group.Name, err)
+		return &pb.ListInvitesResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.ListInvitesResponse{Ok: true}
+	for i := range invites {
+		resp.Invites = append(resp.Invites, toInviteCodeInfo(&invites[i], group.Name, joined[invites[i].ID]))
+	}
+	return resp, nil
+}
+
+// RevokeInvite - Admin thu hồi invite code
+func (s *chatServer) RevokeInvite(ctx context.Context, req *pb.RevokeInviteRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+
+	invite, err := db.GetInviteCode(req.Code)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidInviteCode) {
+			return &pb.GroupActionResponse{Ok: false, Message: "invite not found"}, nil
+		}
+		log.Printf("Error loading invite: %v", err)
+		return &pb.GroupActionResponse{Ok: false, Message: "database error"}, nil
+	}
+	if err := checkGroupRole(&invite.Group, caller, database.GroupRoleAdmin); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.RevokeInviteCode(invite.ID); err != nil {
+		if errors.Is(err, database.ErrInvalidInviteCode) {
+			return &pb.GroupActionResponse{Ok: false, Message: "invite already revoked"}, nil
+		}
+		log.Printf("Error revoking invite %d: %v", invite.ID, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to revoke invite"}, nil
+	}
+
+	log.Printf("[GROUP %s] %s revoked invite %d", invite.Group.Name, caller, invite.ID)
+	return &pb.GroupActionResponse{Ok: true, Message: "invite revoked"}, nil
+}
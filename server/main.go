@@ -3,27 +3,46 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	pb "chat-grpc/proto"
 	"chat-grpc/database"
+	"chat-grpc/storage"
 
+	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type clientSession struct {
-	username string
-	send     chan *pb.ChatMessage
+	username  string
+	sessionID uint
+	apiKeyID  uint
+	isBot     bool
+	send      chan *pb.ChatMessage
+	kick      chan error // nhận lý do khi server chủ động ngắt stream
+}
+
+// terminate asks the ChatStream handler to end the stream with reason
+func (c *clientSession) terminate(reason error) {
+	select {
+	case c.kick <- reason:
+	default:
+	}
 }
 
 var (
-	db *database.DB
+	db    *database.DB
+	blobs storage.BlobStore
 )
 
 // Server implementation
@@ -31,16 +50,28 @@ type chatServer struct {
 	pb.UnimplementedChatServiceServer
 	mu      sync.RWMutex
 	clients map[string]*clientSession
+	policy  *credentialPolicy
+	typing  *typingTracker
 }
 
-func newServer() *chatServer {
+func newServer(policy *credentialPolicy) *chatServer {
 	return &chatServer{
 		clients: make(map[string]*clientSession),
+		policy:  policy,
+		typing:  newTypingTracker(),
 	}
 }
 
 // Register unary
 func (s *chatServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	// Kiểm tra username và password theo policy
+	if err := s.policy.validateUsername(req.Username); err != nil {
+		return &pb.RegisterResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if err := s.policy.validatePassword(req.Username, req.Password); err != nil {
+		return &pb.RegisterResponse{Ok: false, Message: err.Error()}, nil
+	}
+
 	// Kiểm tra username đã tồn tại chưa
 	exists, err := db.UserExists(req.Username)
 	if err != nil {
@@ -59,6 +90,11 @@ func (s *chatServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb
 		return &pb.RegisterResponse{Ok: false, Message: "failed to create user"}, nil
 	}
 
+	// User mới vào workspace mặc định
+	if err := db.JoinDefaultWorkspace(req.Username); err != nil {
+		log.Printf("Error adding %s to default workspace: %v", req.Username, err)
+	}
+
 	log.Printf("User registered: %s", req.Username)
 	return &pb.RegisterResponse{Ok: true, Message: "registered successfully"}, nil
 }
@@ -66,27 +102,111 @@ func (s *chatServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb
 // Login
 func (s *chatServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// Authenticate user với bcrypt password check
-	_, err := db.AuthenticateUser(req.Username, req.Password)
+	user, err := db.AuthenticateUser(req.Username, req.Password)
 	if err != nil {
 		log.Printf("Failed login attempt for %s: %v", req.Username, err)
 		return &pb.LoginResponse{Ok: false, Message: "invalid credentials"}, nil
 	}
+	if user.DisabledAt != nil {
+		log.Printf("Login attempt for disabled account %s", req.Username)
+		return &pb.LoginResponse{Ok: false, Message: "account disabled"}, nil
+	}
+
+	// Tạo session phía server
+	sess, token, err := db.CreateSession(req.Username, sessionTTL)
+	if err != nil {
+		log.Printf("Error creating session for %s: %v", req.Username, err)
+		return &pb.LoginResponse{Ok: false, Message: "failed to create session"}, nil
+	}
+
+	log.Printf("User logged in: %s (session %d)", req.Username, sess.ID)
+	return &pb.LoginResponse{Ok: true, Message: "login successful", Token: token, SessionId: int64(sess.ID)}, nil
+}
+
+// ChangePassword - Đổi mật khẩu, yêu cầu mật khẩu cũ và hủy các session khác
+func (s *chatServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	auth := authFromContext(ctx)
+	if req.Username != "" && req.Username != auth.username {
+		return &pb.ChangePasswordResponse{Ok: false, Message: "cannot change another user's password"}, nil
+	}
+
+	if _, err := db.AuthenticateUser(auth.username, req.OldPassword); err != nil {
+		log.Printf("Failed password change for %s: %v", auth.username, err)
+		return &pb.ChangePasswordResponse{Ok: false, Message: "invalid credentials"}, nil
+	}
 
-	log.Printf("User logged in: %s", req.Username)
-	return &pb.LoginResponse{Ok: true, Message: "login successful"}, nil
+	if req.NewPassword == req.OldPassword {
+		return &pb.ChangePasswordResponse{Ok: false, Message: "new password must differ from the old one"}, nil
+	}
+	if err := s.policy.validatePassword(auth.username, req.NewPassword); err != nil {
+		return &pb.ChangePasswordResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.UpdatePassword(auth.username, req.NewPassword); err != nil {
+		log.Printf("Error updating password for %s: %v", auth.username, err)
+		return &pb.ChangePasswordResponse{Ok: false, Message: "failed to change password"}, nil
+	}
+
+	// Giữ session hiện tại, hủy các session còn lại
+	revoked, err := db.RevokeUserSessions(auth.username, auth.sessionID)
+	if err != nil {
+		log.Printf("Error revoking sessions of %s: %v", auth.username, err)
+	}
+	s.closeSessions(revoked, errSessionRevoked)
+
+	log.Printf("Password changed: %s (%d other sessions revoked)", auth.username, len(revoked))
+	return &pb.ChangePasswordResponse{Ok: true, Message: "password changed"}, nil
 }
 
-// List users (chỉ những user đang online)
+// ResetPassword - Đặt lại mật khẩu bằng reset token do admin cấp
+func (s *chatServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	if err := s.policy.validatePassword(req.Username, req.NewPassword); err != nil {
+		return &pb.ResetPasswordResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.ConsumePasswordReset(req.Username, req.ResetToken, req.NewPassword); err != nil {
+		if errors.Is(err, database.ErrInvalidResetToken) {
+			log.Printf("Invalid reset token used for %s", req.Username)
+			return &pb.ResetPasswordResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error resetting password for %s: %v", req.Username, err)
+		return &pb.ResetPasswordResponse{Ok: false, Message: "failed to reset password"}, nil
+	}
+
+	// Reset mật khẩu thì hủy mọi session cũ
+	revoked, err := db.RevokeUserSessions(req.Username, 0)
+	if err != nil {
+		log.Printf("Error revoking sessions of %s: %v", req.Username, err)
+	}
+	s.closeSessions(revoked, errSessionRevoked)
+
+	log.Printf("Password reset: %s", req.Username)
+	return &pb.ResetPasswordResponse{Ok: true, Message: "password reset, please log in"}, nil
+}
+
+// List users (chỉ những user đang online, cùng workspace với caller)
 func (s *chatServer) ListUsers(ctx context.Context, _ *pb.Empty) (*pb.ListUsersResponse, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	online := make(map[string]bool, len(s.clients))
+	names := make([]string, 0, len(s.clients))
+	for name, c := range s.clients {
+		online[name] = c.isBot
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
 
 	resp := &pb.ListUsersResponse{}
-	for name := range s.clients {
+	peers, err := db.GetWorkspacePeers(callerName(ctx), names)
+	if err != nil {
+		log.Printf("Error filtering workspace peers: %v", err)
+		return resp, nil
+	}
+	for _, name := range peers {
 		resp.Users = append(resp.Users, &pb.UserInfo{
 			Username:    name,
 			DisplayName: name,
 			IsOnline:    true,
+			IsBot:       online[name],
 		})
 	}
 	return resp, nil
@@ -107,8 +227,8 @@ func (s *chatServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest
 		limit = 20
 	}
 
-	// Tìm kiếm trong database với fuzzy search
-	users, err := db.SearchUsers(req.Query, limit)
+	// Tìm kiếm trong database với fuzzy search, chỉ trong các workspace của caller
+	users, err := db.SearchUsers(req.Query, callerName(ctx), limit)
 	if err != nil {
 		log.Printf("Error searching users for query '%s': %v", req.Query, err)
 		return resp, nil
@@ -120,6 +240,7 @@ func (s *chatServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest
 			Username:    user.Username,
 			DisplayName: user.DisplayName,
 			IsOnline:    user.IsOnline,
+			IsBot:       user.IsBot,
 		})
 	}
 
@@ -127,42 +248,79 @@ func (s *chatServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest
 	return resp, nil
 }
 
-// GetUserGroups - Lấy danh sách groups mà user đã join
-func (s *chatServer) GetUserGroups(ctx context.Context, req *pb.GetUserGroupsRequest) (*pb.GetUserGroupsResponse, error) {
+// GetUserGroups - Lấy danh sách groups mà caller đã join. Luôn là caller: unread count,
+// read cursor và group private của người khác không được lộ ra.
+func (s *chatServer) GetUserGroups(ctx context.Context, _ *pb.GetUserGroupsRequest) (*pb.GetUserGroupsResponse, error) {
 	resp := &pb.GetUserGroupsResponse{}
+	username := callerName(ctx)
 
 	// Lấy groups từ database
-	groups, err := db.GetUserGroups(req.Username)
+	groups, err := db.GetUserGroups(username)
 	if err != nil {
-		log.Printf("Error getting user groups for %s: %v", req.Username, err)
+		log.Printf("Error getting user groups for %s: %v", username, err)
 		return resp, nil
 	}
 
+	// Số tin chưa đọc của mọi group trong một query
+	ids := make([]uint, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.ID)
+	}
+	unread, err := db.GetGroupUnreadCounts(username, ids)
+	if err != nil {
+		log.Printf("Error counting unread messages for %s: %v", username, err)
+	}
+	lastRead := make(map[uint]uint)
+	if cursors, err := db.GetReadCursors(username); err == nil {
+		for _, c := range cursors {
+			if c.Peer == "" {
+				lastRead[c.GroupID] = c.LastReadID
+			}
+		}
+	}
+
 	// Chuyển đổi sang protobuf response
-	for _, group := range groups {
-		// Lấy members của group
-		members, err := db.GetGroupMembers(group.Name)
+	slugs := workspaceSlugs(username)
+	for i := range groups {
+		// Lấy members của group kèm role
+		members, err := db.GetGroupMemberDetails(groups[i].ID)
 		if err != nil {
-			log.Printf("Error getting group members for %s: %v", group.Name, err)
+			log.Printf("Error getting group members for %s: %v", groups[i].Name, err)
 			continue
 		}
-
-		resp.Groups = append(resp.Groups, &pb.GroupInfo{
-			Name:    group.Name,
-			Members: members,
-		})
+		info := toGroupInfo(&groups[i], members, username)
+		if groups[i].WorkspaceID != nil {
+			info.Workspace = slugs[*groups[i].WorkspaceID]
+		}
+		info.UnreadCount = int32(unread[groups[i].ID])
+		info.LastReadId = int64(lastRead[groups[i].ID])
+		resp.Groups = append(resp.Groups, info)
 	}
 
 	return resp, nil
 }
 
 func (s *chatServer) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
-	if req.GroupName == "" {
-		return &pb.CreateGroupResponse{Ok: false, Message: "empty group name"}, nil
+	if err := validateGroupName(req.GroupName); err != nil {
+		return &pb.CreateGroupResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if req.Visibility != "" && !database.ValidGroupVisibility(req.Visibility) {
+		return &pb.CreateGroupResponse{Ok: false, Message: "visibility must be public, private or invite_only"}, nil
+	}
+	if req.Kind != "" && !database.ValidGroupKind(req.Kind) {
+		return &pb.CreateGroupResponse{Ok: false, Message: "kind must be group or channel"}, nil
+	}
+
+	// Người tạo group là owner
+	creator := callerName(ctx)
+
+	ws, err := resolveWorkspace(creator, req.Workspace)
+	if err != nil {
+		return &pb.CreateGroupResponse{Ok: false, Message: err.Error()}, nil
 	}
 
-	// Kiểm tra group đã tồn tại chưa
-	exists, err := db.GroupExists(req.GroupName)
+	// Kiểm tra group đã tồn tại chưa (tên group unique trong workspace)
+	exists, err := db.GroupExists(ws.ID, req.GroupName)
 	if err != nil {
 		log.Printf("Error checking group existence: %v", err)
 		return &pb.CreateGroupResponse{Ok: false, Message: "database error"}, nil
@@ -172,73 +330,136 @@ func (s *chatServer) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest
 		return &pb.CreateGroupResponse{Ok: false, Message: "group already exists"}, nil
 	}
 
-	// Đảm bảo có ít nhất creator trong group
-	membersList := req.Members
-	if len(membersList) == 0 {
-		return &pb.CreateGroupResponse{Ok: false, Message: "creator must be included in members"}, nil
-	}
-
 	// Tạo group trong database
-	_, err = db.CreateGroup(req.GroupName)
+	group, err := db.CreateGroup(ws.ID, req.GroupName, req.Kind, req.Visibility)
 	if err != nil {
 		log.Printf("Error creating group: %v", err)
 		return &pb.CreateGroupResponse{Ok: false, Message: "failed to create group"}, nil
 	}
 
-	// Thêm members vào group
-	for _, m := range membersList {
-		if err := db.AddGroupMember(req.GroupName, m); err != nil {
+	if err := db.AddGroupMemberWithRole(group.ID, creator, database.GroupRoleOwner); err != nil {
+		log.Printf("Error adding owner %s to group %s: %v", creator, req.GroupName, err)
+		return &pb.CreateGroupResponse{Ok: false, Message: "failed to create group"}, nil
+	}
+
+	// Thêm members còn lại vào group (phải cùng workspace)
+	for _, m := range req.Members {
+		if m == creator {
+			continue
+		}
+		if err := requireGroupWorkspace(group, m); err != nil {
+			log.Printf("Skipping member %s of group %s: %v", m, req.GroupName, err)
+			continue
+		}
+		if err := db.AddGroupMember(group.ID, m); err != nil {
 			log.Printf("Error adding member %s to group %s: %v", m, req.GroupName, err)
 		}
 	}
 
-	log.Printf("Group created: %s with %d members (creator: %s)", req.GroupName, len(membersList), membersList[0])
+	log.Printf("Group created: %s/%s (%s, %s) with %d initial members (owner: %s)", ws.Slug, req.GroupName, group.Kind, group.Visibility, len(req.Members), creator)
 	return &pb.CreateGroupResponse{Ok: true, Message: "group created and you've joined"}, nil
 }
 
 func (s *chatServer) JoinGroup(ctx context.Context, req *pb.JoinGroupRequest) (*pb.JoinGroupResponse, error) {
-	// Kiểm tra group đã tồn tại chưa
-	exists, err := db.GroupExists(req.GroupName)
+	caller := callerName(ctx)
+	username := req.Username
+	if username == "" {
+		username = caller
+	}
+
+	group, err := loadGroup(req.GroupId, req.GroupName, caller)
 	if err != nil {
-		log.Printf("Error checking group existence: %v", err)
+		return &pb.JoinGroupResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	// Thêm người khác vào group = gửi lời mời, họ phải accept
+	if username != caller {
+		msg, err := s.inviteUser(group, caller, username)
+		if err != nil {
+			return &pb.JoinGroupResponse{Ok: false, Message: err.Error()}, nil
+		}
+		return &pb.JoinGroupResponse{Ok: true, Message: msg}, nil
+	}
+
+	member, err := db.IsGroupMember(group.ID, username)
+	if err != nil {
+		log.Printf("Error checking membership of %s in %s: %v", username, group.Name, err)
+		return &pb.JoinGroupResponse{Ok: false, Message: "database error"}, nil
+	}
+	if member {
+		return &pb.JoinGroupResponse{Ok: false, Message: "already a member"}, nil
+	}
+	if err := requireGroupWorkspace(group, username); err != nil {
+		return &pb.JoinGroupResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	ban, err := db.GetActiveGroupBan(group.ID, username)
+	if err != nil {
+		log.Printf("Error checking ban of %s in %s: %v", username, group.Name, err)
 		return &pb.JoinGroupResponse{Ok: false, Message: "database error"}, nil
 	}
+	if ban != nil {
+		return &pb.JoinGroupResponse{Ok: false, Message: banMessage(group.Name, ban)}, nil
+	}
 
-	// Tạo group nếu chưa tồn tại
-	if !exists {
-		_, err := db.CreateGroup(req.GroupName)
+	switch group.Visibility {
+	case database.GroupPrivate:
+		// Group private: tạo join request chờ admin duyệt
+		jr, err := db.CreateJoinRequest(group.ID, username)
+		if errors.Is(err, database.ErrRequestExists) {
+			return &pb.JoinGroupResponse{Ok: false, Message: "join request already pending"}, nil
+		}
 		if err != nil {
-			log.Printf("Error creating group: %v", err)
-			return &pb.JoinGroupResponse{Ok: false, Message: "failed to create group"}, nil
+			log.Printf("Error creating join request of %s for %s: %v", username, group.Name, err)
+			return &pb.JoinGroupResponse{Ok: false, Message: "failed to request join"}, nil
 		}
+		s.notifyGroupAdmins(group, fmt.Sprintf("%s asks to join %s (request #%d)", username, group.Name, jr.ID))
+		log.Printf("User %s requested to join group: %s", username, group.Name)
+		return &pb.JoinGroupResponse{Ok: true, Message: "join request sent, waiting for approval"}, nil
+
+	case database.GroupInviteOnly:
+		return &pb.JoinGroupResponse{Ok: false, Message: "group is invite-only"}, nil
 	}
 
 	// Thêm user vào group
-	if err := db.AddGroupMember(req.GroupName, req.Username); err != nil {
-		log.Printf("Error adding user %s to group %s: %v", req.Username, req.GroupName, err)
+	if err := db.AddGroupMember(group.ID, username); err != nil {
+		log.Printf("Error adding user %s to group %s: %v", username, group.Name, err)
 		return &pb.JoinGroupResponse{Ok: false, Message: "failed to join group"}, nil
 	}
 
-	log.Printf("User %s joined group: %s", req.Username, req.GroupName)
+	log.Printf("User %s joined group: %s", username, group.Name)
 	return &pb.JoinGroupResponse{Ok: true, Message: "joined successfully"}, nil
 }
 
 // ChatStream bi-directional
 func (s *chatServer) ChatStream(stream pb.ChatService_ChatStreamServer) error {
-	// Nhận message đầu tiên để lấy username
+	// Stream đã được xác thực bởi interceptor, gắn với session của user
+	auth := authFromContext(stream.Context())
+	username := auth.username
+
+	// Nhận message đầu tiên (connect)
 	initMsg, err := stream.Recv()
 	if err != nil {
 		return err
 	}
-	username := initMsg.From
-	if username == "" {
-		return errors.New("must send initial message with From=username")
+	if initMsg.From != "" && initMsg.From != username {
+		return status.Errorf(codes.PermissionDenied, "session belongs to %s", username)
+	}
+
+	user, err := db.GetUserByUsername(username)
+	if err != nil {
+		log.Printf("Error loading user %s: %v", username, err)
+		return status.Error(codes.Internal, "database error")
 	}
 
 	// Tạo session
 	sess := &clientSession{
-		username: username,
-		send:     make(chan *pb.ChatMessage, 100),
+		username:  username,
+		sessionID: auth.sessionID,
+		apiKeyID:  auth.apiKeyID,
+		isBot:     user.IsBot,
+		send:      make(chan *pb.ChatMessage, 100),
+		kick:      make(chan error, 1),
 	}
 
 	// Đăng ký client
@@ -255,7 +476,11 @@ func (s *chatServer) ChatStream(stream pb.ChatService_ChatStreamServer) error {
 		log.Printf("Error updating user online status: %v", err)
 	}
 
-	log.Printf("User connected: %s", username)
+	if auth.apiKeyID != 0 {
+		log.Printf("User connected: %s (api key %d)", username, auth.apiKeyID)
+	} else {
+		log.Printf("User connected: %s (session %d)", username, auth.sessionID)
+	}
 
 	// Goroutine để gửi messages đến client
 	done := make(chan struct{})
@@ -271,33 +496,52 @@ func (s *chatServer) ChatStream(stream pb.ChatService_ChatStreamServer) error {
 
 	// Xử lý initial message nếu có text
 	if initMsg.Text != "" && initMsg.Type != "connect" {
+		initMsg.From = username
 		initMsg.Timestamp = time.Now().Unix()
 		s.handleIncoming(initMsg)
 	}
 
-	// Read loop
-	for {
-		msg, err := stream.Recv()
-		if err != nil {
-			if err == io.EOF {
-				log.Printf("client %s closed connection", username)
-			} else {
-				log.Printf("client %s error: %v", username, err)
+	// Read loop chạy riêng để có thể ngắt stream khi session bị hủy
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
 			}
-			s.removeClient(username)
-			<-done // Đợi goroutine gửi kết thúc
-			return nil
+			msg.From = username // Không tin From do client gửi
+			msg.Timestamp = time.Now().Unix()
+			s.handleIncoming(msg)
 		}
-		msg.Timestamp = time.Now().Unix()
-		s.handleIncoming(msg)
+	}()
+
+	select {
+	case err := <-recvErr:
+		if err == io.EOF {
+			log.Printf("client %s closed connection", username)
+		} else {
+			log.Printf("client %s error: %v", username, err)
+		}
+		s.removeClient(sess)
+		s.stopUserTyping(username)
+		<-done // Đợi goroutine gửi kết thúc
+		return nil
+	case reason := <-sess.kick:
+		log.Printf("client %s disconnected by server: %v", username, reason)
+		s.removeClient(sess)
+		s.stopUserTyping(username)
+		<-done
+		return reason
 	}
 }
 
-func (s *chatServer) removeClient(username string) {
+func (s *chatServer) removeClient(sess *clientSession) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if c, ok := s.clients[username]; ok {
+	username := sess.username
+	if c, ok := s.clients[username]; ok && c == sess {
 		close(c.send)
 		delete(s.clients, username)
 
@@ -311,19 +555,66 @@ func (s *chatServer) removeClient(username string) {
 	// Note: Không xóa user khỏi groups khi disconnect, giữ membership
 }
 
-func (s *chatServer) handleIncoming(msg *pb.ChatMessage) {
-	// Lưu message vào database
-	if err := db.SaveMessage(msg.From, msg.To, msg.Type, msg.Text); err != nil {
-		log.Printf("Error saving message: %v", err)
+// handleIncoming xử lý message từ client. accepted là false khi message bị từ chối
+// (người gửi đã nhận event error); message được nhận nhưng lưu lỗi vẫn là accepted.
+func (s *chatServer) handleIncoming(msg *pb.ChatMessage) (accepted bool) {
+	// Reply đi theo conversation của message được trả lời
+	var parent *database.Message
+	if msg.ReplyTo != 0 {
+		var err error
+		if parent, err = s.replyParent(msg); err != nil {
+			s.notify(msg.From, "error", msg.To, err.Error())
+			return false
+		}
 	}
 
 	switch msg.Type {
 	case "private":
+		// Chỉ nhắn riêng được cho người cùng workspace
+		shared, err := db.SharesWorkspace(msg.From, msg.To)
+		if err != nil {
+			log.Printf("Error checking workspaces of %s and %s: %v", msg.From, msg.To, err)
+			return false
+		}
+		if !shared {
+			s.notify(msg.From, "error", msg.To, fmt.Sprintf("you do not share a workspace with %s", msg.To))
+			return false
+		}
+
+		attachments, err := pendingAttachments(msg)
+		if err != nil {
+			s.notify(msg.From, "error", msg.To, err.Error())
+			return false
+		}
+
+		// Lưu message vào database
+		var saved *database.Message
+		if parent != nil {
+			saved, err = saveReply(msg, parent, nil)
+		} else {
+			saved, err = db.SaveMessage(msg.From, msg.To, msg.Type, msg.Text)
+		}
+		if err != nil {
+			log.Printf("Error saving message: %v", err)
+		} else {
+			msg.Id = int64(saved.ID)
+			setExpiry(msg, saved)
+		}
+		linkAttachments(msg, saved, attachments)
+		// Message mới thay cho event stop typing
+		s.typing.clear(msg.From, privateConversation(msg.To))
+
 		s.mu.RLock()
 		target, ok := s.clients[msg.To]
 		s.mu.RUnlock()
 
 		if ok {
+			// Người nhận đã mute chat riêng này
+			muted, err := db.IsConversationMuted(msg.To, 0, msg.From)
+			if err != nil {
+				log.Printf("Error checking mute of %s: %v", msg.To, err)
+			}
+			msg.Mentions, msg.Mentioned, msg.Muted = nil, false, muted
 			select {
 			case target.send <- msg:
 				log.Printf("[PM] %s -> %s: %s", msg.From, msg.To, msg.Text)
@@ -335,40 +626,90 @@ func (s *chatServer) handleIncoming(msg *pb.ChatMessage) {
 		}
 
 	case "group":
-		// Lấy members từ database
-		members, err := db.GetGroupMembers(msg.To)
+		// Group addressed by ID (or by name for older clients); kiểm tra quyền gửi
+		group, err := s.groupForPosting(msg.GroupId, msg.To, msg.From)
 		if err != nil {
-			log.Printf("Error getting group members for %s: %v", msg.To, err)
-			return
+			s.notify(msg.From, "error", msg.To, err.Error())
+			return false
 		}
+		msg.To = group.Name
+		msg.GroupId = int64(group.ID)
 
-		s.mu.RLock()
-		clientsSnapshot := make(map[string]*clientSession)
-		for _, member := range members {
-			if member == msg.From {
-				continue // Không gửi lại cho người gửi
-			}
-			if c, online := s.clients[member]; online {
-				clientsSnapshot[member] = c
-			}
+		attachments, err := pendingAttachments(msg)
+		if err != nil {
+			s.notify(msg.From, "error", msg.To, err.Error())
+			return false
 		}
-		s.mu.RUnlock()
 
-		log.Printf("[GROUP %s] %s: %s (to %d members)", msg.To, msg.From, msg.Text, len(clientsSnapshot))
-		for _, c := range clientsSnapshot {
-			select {
-			case c.send <- msg:
-			default:
-				log.Printf("member buffer full in group %s", msg.To)
-			}
+		// Mention do server parse, client không tự khai báo
+		entities, mentions, deniedAll := s.parseMentions(group, msg.From, msg.Text)
+		if deniedAll {
+			s.mentionAllDenied(group, msg.From)
+		}
+		msg.Mentions = toMentions(entities)
+		msg.Mentioned, msg.Muted = false, false
+
+		var saved *database.Message
+		if parent != nil {
+			saved, err = saveReply(msg, parent, group)
+		} else {
+			saved, err = db.SaveGroupMessage(group, msg.From, msg.Type, msg.Text)
+		}
+		if err != nil {
+			log.Printf("Error saving message: %v", err)
+		} else {
+			msg.Id = int64(saved.ID)
+			setExpiry(msg, saved)
+		}
+		linkAttachments(msg, saved, attachments)
+		s.storeMentions(saved, group, entities, mentions)
+		s.typing.clear(msg.From, groupConversation(group.ID))
+
+		// Reply chỉ gửi tới những người tham gia thread và người được mention
+		var root uint
+		if parent != nil {
+			root = parent.Root()
+		}
+		delivered := s.deliverGroupMessage(group, msg, root, mentions) // Không gửi lại cho người gửi
+		if parent != nil {
+			log.Printf("[GROUP %s] %s replied in thread %d: %s (to %d members)", msg.To, msg.From, root, msg.Text, delivered)
+			return true
+		}
+		log.Printf("[GROUP %s] %s: %s (to %d members)", msg.To, msg.From, msg.Text, delivered)
+
+	case "typing":
+		// Ephemeral, không lưu database
+		s.handleTyping(msg)
+
+	case "read":
+		// Đánh dấu đã đọc qua stream, như MarkRead
+		req := &pb.MarkReadRequest{ChatType: msg.ChatType, Target: msg.To, GroupId: msg.GroupId, MessageId: msg.Id}
+		if _, err := s.markRead(msg.From, req); err != nil {
+			s.notify(msg.From, "error", msg.To, err.Error())
+			return false
 		}
 
 	default:
 		log.Printf("unknown msg type: %s from %s", msg.Type, msg.From)
+		return false
 	}
+	return true
 }
 
 func main() {
+	minPasswordLength := flag.Int("min-password-length", 8, "minimum password length")
+	breachList := flag.String("breach-list", "", "file of breached passwords to reject, one per line")
+	bcryptCost := flag.Int("bcrypt-cost", 12, "bcrypt cost for password hashes; weaker hashes are upgraded on login")
+	issueReset := flag.String("issue-reset", "", "issue a password reset token for `username` and exit")
+	grantRole := flag.String("grant-role", "", "set the server role of a user as `username:role` and exit")
+	blobStore := flag.String("blob-store", "local", "where uploaded files are kept: local or s3-local")
+	blobDir := flag.String("blob-dir", "uploads", "directory of the local blob store")
+	blobBucket := flag.String("blob-bucket", "chat-attachments", "bucket of the s3-local blob store")
+	retentionInterval := flag.Duration("retention-interval", time.Hour, "how often retention policies purge old messages; 0 disables retention")
+	archiveDir := flag.String("archive-dir", "archive", "directory of gzip JSONL archives of purged messages; empty disables archiving")
+	partitionMessages := flag.Bool("partition-messages", false, "convert the messages table to monthly partitions and exit (stop other servers first)")
+	flag.Parse()
+
 	// Setup logging
 	f, err := os.OpenFile("server.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
@@ -389,14 +730,85 @@ func main() {
 
 	log.Println("Database connection established")
 
+	// Password policy
+	if *bcryptCost < bcrypt.MinCost || *bcryptCost > bcrypt.MaxCost {
+		log.Fatalf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	database.PasswordCost = *bcryptCost
+
+	policy := defaultCredentialPolicy()
+	policy.MinPasswordLength = *minPasswordLength
+	if *breachList != "" {
+		if err := policy.loadBreachList(*breachList); err != nil {
+			log.Fatalf("failed to load breach list: %v", err)
+		}
+		log.Printf("Loaded %d breached passwords from %s", len(policy.breached), *breachList)
+	}
+
+	// Admin cấp reset token từ máy chủ rồi thoát
+	if *issueReset != "" {
+		token, err := db.CreatePasswordReset(*issueReset, "server-cli", time.Hour)
+		if err != nil {
+			log.Fatalf("failed to issue reset token for %s: %v", *issueReset, err)
+		}
+		log.Printf("Password reset token issued for %s", *issueReset)
+		fmt.Printf("Reset token for %s (valid 1h): %s\n", *issueReset, token)
+		return
+	}
+
+	// Cấp role (ví dụ tạo admin đầu tiên) rồi thoát
+	if *grantRole != "" {
+		username, role, ok := strings.Cut(*grantRole, ":")
+		if !ok || !database.ValidRole(role) {
+			log.Fatalf("-grant-role expects username:role with role one of user, moderator, admin")
+		}
+		if err := db.SetUserRole(username, role); err != nil {
+			log.Fatalf("failed to set role of %s: %v", username, err)
+		}
+		if err := db.RecordAudit("server-cli", "set_role", username, role); err != nil {
+			log.Printf("Error recording audit log: %v", err)
+		}
+		log.Printf("Role of %s set to %s", username, role)
+		return
+	}
+
+	// Chuyển bảng messages sang partition theo tháng rồi thoát
+	if *partitionMessages {
+		if err := db.PartitionMessages(time.Now(), partitionsAhead); err != nil {
+			log.Fatalf("failed to partition messages: %v", err)
+		}
+		log.Println("Messages table partitioned by month")
+		return
+	}
+
+	// Blob store cho file đính kèm
+	blobs, err = openBlobStore(*blobStore, *blobDir, *blobBucket)
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
+	go sweepAttachments()
+
 	// Setup gRPC server
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	grpcSrv := grpc.NewServer()
-	pb.RegisterChatServiceServer(grpcSrv, newServer())
+	srv := newServer(policy)
+	go srv.runScheduler()
+	go srv.runExpirySweeper()
+	// -retention-interval 0 tắt retention trên instance này
+	if *retentionInterval > 0 {
+		go runRetention(*retentionInterval, *archiveDir)
+	} else {
+		log.Println("Message retention disabled")
+	}
+	grpcSrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(srv.unaryAuthInterceptor, srv.adminRoleInterceptor),
+		grpc.ChainStreamInterceptor(srv.streamAuthInterceptor),
+	)
+	pb.RegisterChatServiceServer(grpcSrv, srv)
+	pb.RegisterAdminServiceServer(grpcSrv, &adminServer{chat: srv})
 
 	log.Println("=================================")
 	log.Println("gRPC Chat Server listening on :50051")
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// maxBanDuration giới hạn thời gian ban có thời hạn
+const maxBanDuration = 365 * 24 * time.Hour
+
+// banMessage mô tả ban cho user bị ban
+func banMessage(groupName string, ban *database.GroupBan) string {
+	msg := "you are banned from " + groupName
+	if ban.ExpiresAt != nil {
+		msg += " until " + ban.ExpiresAt.Format("2006-01-02 15:04")
+	}
+	if ban.Reason != "" {
+		msg += ": " + ban.Reason
+	}
+	return msg
+}
+
+// requireOutranks checks caller is a group admin with a higher role than target.
+// target không cần là member (ban trước khi join); khi đó chỉ cần admin.
+func (s *chatServer) requireOutranks(groupID int64, groupName, caller, target string) (*database.Group, error) {
+	if caller == target {
+		return nil, errors.New("use LeaveGroup to leave a group")
+	}
+	group, err := s.requireGroupRole(groupID, groupName, caller, database.GroupRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	callerRole, err := db.GetGroupMemberRole(group.ID, caller)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	targetRole, err := db.GetGroupMemberRole(group.ID, target)
+	if err != nil && !errors.Is(err, database.ErrNotGroupMember) {
+		log.Printf("Error loading role of %s in %s: %v", target, group.Name, err)
+		return nil, errors.New("database error")
+	}
+	if targetRole != "" && database.GroupRoleAtLeast(targetRole, callerRole) {
+		return nil, fmt.Errorf("cannot act on %s (%s)", target, targetRole)
+	}
+	return group, nil
+}
+
+// LeaveGroup - Rời group; owner rời thì chuyển quyền cho admin/member lâu nhất
+func (s *chatServer) LeaveGroup(ctx context.Context, req *pb.GroupNameRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+
+	group, err := loadGroup(req.GroupId, req.GroupName, caller)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	newOwner, empty, err := db.LeaveGroup(group.ID, caller)
+	if err != nil {
+		if errors.Is(err, database.ErrNotGroupMember) {
+			return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error leaving group %s for %s: %v", group.Name, caller, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to leave group"}, nil
+	}
+
+	log.Printf("User %s left group: %s", caller, group.Name)
+	if empty {
+		log.Printf("Group deleted: %s (last member left)", group.Name)
+		return &pb.GroupActionResponse{Ok: true, Message: "left " + group.Name + "; the group was empty and has been deleted"}, nil
+	}
+	if newOwner != "" {
+		s.notify(newOwner, "notice", group.Name, fmt.Sprintf("%s left %s, you are now the owner", caller, group.Name))
+		log.Printf("[GROUP %s] ownership passed from %s to %s", group.Name, caller, newOwner)
+		return &pb.GroupActionResponse{Ok: true, Message: fmt.Sprintf("left %s; %s is the new owner", group.Name, newOwner)}, nil
+	}
+	return &pb.GroupActionResponse{Ok: true, Message: "left " + group.Name}, nil
+}
+
+// RemoveMember - Admin kick member khỏi group (member có thể join lại)
+func (s *chatServer) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	group, err := s.requireOutranks(req.GroupId, req.GroupName, caller, req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.RemoveGroupMember(group.ID, req.Username); err != nil {
+		if errors.Is(err, database.ErrNotGroupMember) {
+			return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("%s is not a member", req.Username)}, nil
+		}
+		log.Printf("Error removing %s from %s: %v", req.Username, group.Name, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to remove member"}, nil
+	}
+
+	text := fmt.Sprintf("you were removed from %s by %s", group.Name, caller)
+	if req.Reason != "" {
+		text += ": " + req.Reason
+	}
+	s.notify(req.Username, "notice", group.Name, text)
+	log.Printf("[GROUP %s] %s removed %s (%s)", group.Name, caller, req.Username, req.Reason)
+	return &pb.GroupActionResponse{Ok: true, Message: req.Username + " removed"}, nil
+}
+
+// BanMember - Admin ban user khỏi group, có thời hạn hoặc vĩnh viễn
+func (s *chatServer) BanMember(ctx context.Context, req *pb.BanMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	if req.DurationSeconds < 0 {
+		return &pb.GroupActionResponse{Ok: false, Message: "duration must not be negative"}, nil
+	}
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if duration > maxBanDuration {
+		return &pb.GroupActionResponse{Ok: false, Message: "duration too long, omit it for a permanent ban"}, nil
+	}
+	if _, err := db.GetUserByUsername(req.Username); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("user %s not found", req.Username)}, nil
+	}
+
+	group, err := s.requireOutranks(req.GroupId, req.GroupName, caller, req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	ban, err := db.BanGroupMember(group.ID, req.Username, caller, req.Reason, duration)
+	if err != nil {
+		log.Printf("Error banning %s from %s: %v", req.Username, group.Name, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to ban user"}, nil
+	}
+
+	s.notify(req.Username, "notice", group.Name, banMessage(group.Name, ban))
+	log.Printf("[GROUP %s] %s banned %s for %s (%s)", group.Name, caller, req.Username, duration, req.Reason)
+	return &pb.GroupActionResponse{Ok: true, Message: req.Username + " banned"}, nil
+}
+
+// UnbanMember - Admin gỡ ban
+func (s *chatServer) UnbanMember(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, caller, database.GroupRoleAdmin)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.UnbanGroupMember(group.ID, req.Username); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	log.Printf("[GROUP %s] %s unbanned %s", group.Name, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: req.Username + " unbanned"}, nil
+}
+
+// ListBans - Admin xem các ban còn hiệu lực
+func (s *chatServer) ListBans(ctx context.Context, req *pb.GroupNameRequest) (*pb.ListBansResponse, error) {
+	group, err := s.requireGroupRole(req.GroupId, req.GroupName, callerName(ctx), database.GroupRoleAdmin)
+	if err != nil {
+		return &pb.ListBansResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	bans, err := db.ListGroupBans(group.ID)
+	if err != nil {
+		log.Printf("Error listing bans of %s: %v", group.Name, err)
+		return &pb.ListBansResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.ListBansResponse{Ok: true}
+	for _, b := range bans {
+		info := &pb.GroupBanInfo{
+			Username:  b.Username,
+			BannedBy:  b.BannedBy,
+			Reason:    b.Reason,
+			CreatedAt: b.CreatedAt.Unix(),
+		}
+		if b.ExpiresAt != nil {
+			info.ExpiresAt = b.ExpiresAt.Unix()
+		}
+		resp.Bans = append(resp.Bans, info)
+	}
+	return resp, nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxMentions         = 50 // mentions parsed per message
+	defaultMentionLimit = 20
+	maxMentionLimit     = 100
+)
+
+// mentionPattern tìm "@name"; ký tự trước "@" không được thuộc tên (bỏ qua email)
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@-])(@[A-Za-z0-9][A-Za-z0-9_.-]*)`)
+
+// mentionTargets là những người một message nhắc tới
+type mentionTargets struct {
+	users []string // members mentioned by name
+	here  bool     // members online when the message is sent
+	all   bool     // every member
+}
+
+func (t mentionTargets) empty() bool {
+	return len(t.users) == 0 && !t.here && !t.all
+}
+
+// mentions reports whether an online recipient is mentioned
+func (t mentionTargets) mentions(name string) bool {
+	return t.all || t.here || slices.Contains(t.users, name)
+}
+
+// parseMentions tách @username, @here, @all trong text của message nhóm.
+// @username chỉ tính khi là member của nhóm; @all cần quyền theo mention policy,
+// không đủ quyền thì deniedAll = true và @all được coi như text thường.
+func (s *chatServer) parseMentions(group *database.Group, from, text string) (entities []database.MentionEntity, targets mentionTargets, deniedAll bool) {
+	matches := mentionPattern.FindAllStringSubmatchIndex(text, maxMentions)
+	if len(matches) == 0 {
+		return nil, targets, false
+	}
+
+	type candidate struct {
+		name   string
+		offset int
+	}
+	candidates := make([]candidate, 0, len(matches))
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		// Dấu chấm / gạch cuối là dấu câu, vd. "cảm ơn @bob."
+		name := strings.TrimRight(text[m[2]+1:m[3]], ".-")
+		candidates = append(candidates, candidate{name: name, offset: m[2]})
+		names = append(names, name)
+	}
+
+	members, err := db.FilterGroupMembers(group.ID, names)
+	if err != nil {
+		log.Printf("Error resolving mentions in %s: %v", group.Name, err)
+	}
+	canMentionAll := slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, "all") }) &&
+		checkGroupRole(group, from, database.PolicyRole(group.MentionPolicy)) == nil
+
+	for _, c := range candidates {
+		e := database.MentionEntity{Offset: c.offset, Length: len(c.name) + 1}
+		switch strings.ToLower(c.name) {
+		case "here":
+			e.Kind = database.MentionHere
+			targets.here = true
+		case "all":
+			if !canMentionAll {
+				deniedAll = true
+				continue
+			}
+			e.Kind = database.MentionAll
+			targets.all = true
+		default:
+			if !slices.Contains(members, c.name) {
+				continue
+			}
+			e.Kind, e.Username = database.MentionUser, c.name
+			if !slices.Contains(targets.users, c.name) {
+				targets.users = append(targets.users, c.name)
+			}
+		}
+		entities = append(entities, e)
+	}
+	return entities, targets, deniedAll
+}
+
+// toMentions converts stored mention entities
+func toMentions(entities []database.MentionEntity) []*pb.Mention {
+	out := make([]*pb.Mention, 0, len(entities))
+	for _, e := range entities {
+		out = append(out, &pb.Mention{Kind: e.Kind, Username: e.Username, Offset: int32(e.Offset), Length: int32(e.Length)})
+	}
+	return out
+}
+
+// storeMentions lưu mention cho mentions inbox; @here là các member online lúc gửi
+func (s *chatServer) storeMentions(saved *database.Message, group *database.Group, entities []database.MentionEntity, targets mentionTargets) {
+	if saved == nil || len(entities) == 0 {
+		return
+	}
+	var here []string
+	if targets.here && !targets.all {
+		online := s.onlineSessions("")
+		names := make([]string, 0, len(online))
+		for name := range online {
+			names = append(names, name)
+		}
+		var err error
+		if here, err = db.FilterGroupMembers(group.ID, names); err != nil {
+			log.Printf("Error resolving @here in %s: %v", group.Name, err)
+		}
+	}
+	if err := db.SaveMentions(saved, entities, targets.users, here, targets.all); err != nil {
+		log.Printf("Error saving mentions of message %d: %v", saved.ID, err)
+	}
+}
+
+// deliverGroupMessage gửi message nhóm mới tới các member online trừ người gửi.
+// Reply trong thread chỉ tới người tham gia thread và người được mention (@here / @all
+// thì tới cả nhóm). Người nhận được mention có cờ mentioned, kể cả khi đã mute nhóm;
+// người mute nhóm có cờ muted.
+func (s *chatServer) deliverGroupMessage(group *database.Group, msg *pb.ChatMessage, threadRoot uint, targets mentionTargets) int {
+	var online map[string]*clientSession
+	var recipients []string
+	if threadRoot != 0 && !targets.here && !targets.all {
+		participants, err := db.GetThreadParticipants(threadRoot)
+		if err != nil {
+			log.Printf("Error loading participants of thread %d: %v", threadRoot, err)
+			return 0
+		}
+		if recipients, err = db.FilterGroupMembers(group.ID, append(participants, targets.users...)); err != nil {
+			log.Printf("Error filtering participants of thread %d: %v", threadRoot, err)
+			return 0
+		}
+		online = s.onlineSessions(msg.From)
+	} else {
+		online, recipients = s.groupRecipients(group, msg.From)
+	}
+
+	present := make([]string, 0, len(recipients))
+	for _, name := range recipients {
+		if _, ok := online[name]; ok {
+			present = append(present, name)
+		}
+	}
+	muted, err := db.MutedGroupMembers(group.ID, present)
+	if err != nil {
+		log.Printf("Error loading mutes of %s: %v", group.Name, err)
+	}
+
+	delivered := 0
+	for _, name := range present {
+		out := msg
+		if mentioned := targets.mentions(name); mentioned || muted[name] {
+			out = proto.Clone(msg).(*pb.ChatMessage)
+			out.Mentioned, out.Muted = mentioned, muted[name]
+		}
+		select {
+		case online[name].send <- out:
+			delivered++
+		default:
+			log.Printf("member buffer full in group %s", group.Name)
+		}
+	}
+	return delivered
+}
+
+// ListMentions - Các tin nhắn nhóm nhắc tới user (mentions inbox), mới nhất trước
+func (s *chatServer) ListMentions(ctx context.Context, req *pb.ListMentionsRequest) (*pb.ListMentionsResponse, error) {
+	caller := callerName(ctx)
+	resp := &pb.ListMentionsResponse{}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultMentionLimit
+	}
+	if limit > maxMentionLimit {
+		limit = maxMentionLimit
+	}
+	var beforeID uint
+	if req.BeforeId > 0 {
+		beforeID = uint(req.BeforeId)
+	}
+
+	// Lấy thêm một dòng để biết còn trang sau không
+	mentions, err := db.ListMentions(caller, beforeID, req.UnreadOnly, limit+1)
+	if err != nil {
+		log.Printf("Error listing mentions of %s: %v", caller, err)
+		return resp, nil
+	}
+	if len(mentions) > limit {
+		mentions = mentions[:limit]
+		resp.NextBeforeId = int64(mentions[limit-1].ID)
+	}
+
+	messages := make([]*pb.ChatMessage, 0, len(mentions))
+	for i := range mentions {
+		m := toChatMessage(&mentions[i].Message)
+		messages = append(messages, m)
+		resp.Mentions = append(resp.Mentions, &pb.MentionItem{Message: m, Kind: mentions[i].MentionKind, Unread: mentions[i].Unread})
+	}
+	attachReactions(messages, caller)
+	attachFiles(messages)
+	return resp, nil
+}
+
+// mentionAllDenied báo cho người gửi khi @all bị bỏ qua vì thiếu quyền
+func (s *chatServer) mentionAllDenied(group *database.Group, from string) {
+	s.notify(from, "notice", group.Name, fmt.Sprintf("@all was not notified: only %s can mention everyone in %s", group.MentionPolicy, group.Name))
+}
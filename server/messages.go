@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// loadMessage loads a message and checks caller may read it: private messages
+// only by their two participants, group messages by whoever can read the group
+func (s *chatServer) loadMessage(id int64, caller string) (*database.Message, *database.Group, error) {
+	m, err := db.GetMessage(uint(id))
+	if err != nil {
+		if errors.Is(err, database.ErrMessageNotFound) {
+			return nil, nil, err
+		}
+		log.Printf("Error loading message %d: %v", id, err)
+		return nil, nil, errors.New("database error")
+	}
+
+	if m.GroupID == nil {
+		if m.FromUser != caller && m.ToTarget != caller {
+			return nil, nil, database.ErrMessageNotFound
+		}
+		return m, nil, nil
+	}
+	group, err := s.groupForReading(int64(*m.GroupID), "", caller)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, group, nil
+}
+
+// messageEvent builds a stream event about a stored message
+func messageEvent(m *database.Message, eventType, actor string) *pb.ChatMessage {
+	ev := toChatMessage(m)
+	ev.Type = eventType
+	ev.From = actor
+	ev.Timestamp = time.Now().Unix()
+	return ev
+}
+
+// pushMessageEvent gửi event về message tới những người đang online thấy message
+func (s *chatServer) pushMessageEvent(m *database.Message, group *database.Group, ev *pb.ChatMessage) {
+	eventType, actor := ev.Type, ev.From
+
+	if group != nil {
+		ev.To = group.Name
+		delivered := s.fanoutGroup(group, ev, "")
+		log.Printf("[GROUP %s] %s %s message %d (to %d members)", group.Name, actor, eventType, m.ID, delivered)
+		return
+	}
+
+	// Chat riêng: gửi cho cả hai phía
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, name := range []string{m.FromUser, m.ToTarget} {
+		c, ok := s.clients[name]
+		if !ok {
+			continue
+		}
+		select {
+		case c.send <- ev:
+		default:
+			log.Printf("user %s buffer full, dropping %s event", name, eventType)
+		}
+	}
+	log.Printf("[PM] %s %s message %d", actor, eventType, m.ID)
+}
+
+// EditMessage - Tác giả sửa nội dung message, nội dung cũ được lưu vào edit history
+func (s *chatServer) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return &pb.MessageActionResponse{Ok: false, Message: "empty message"}, nil
+	}
+
+	m, group, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if m.FromUser != caller {
+		return &pb.MessageActionResponse{Ok: false, Message: "only the author can edit a message"}, nil
+	}
+	if m.Deleted() {
+		return &pb.MessageActionResponse{Ok: false, Message: database.ErrMessageDeleted.Error()}, nil
+	}
+	if m.Text == text {
+		return &pb.MessageActionResponse{Ok: true, Message: "message unchanged"}, nil
+	}
+
+	m, err = db.EditMessage(m.ID, caller, text)
+	if err != nil {
+		if errors.Is(err, database.ErrMessageNotFound) || errors.Is(err, database.ErrMessageDeleted) {
+			return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error editing message %d: %v", req.MessageId, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to edit message"}, nil
+	}
+
+	// Mention được parse lại theo text mới, nhưng không gửi thông báo mới
+	if group != nil {
+		entities, _, _ := s.parseMentions(group, caller, text)
+		if err := db.SetMessageMentions(m, entities); err != nil {
+			log.Printf("Error updating mentions of message %d: %v", m.ID, err)
+		}
+	}
+	s.pushMessageEvent(m, group, messageEvent(m, "edit", caller))
+	return &pb.MessageActionResponse{Ok: true, Message: "message edited"}, nil
+}
+
+// DeleteMessage - Tác giả hoặc admin nhóm xóa message, history giữ lại tombstone
+func (s *chatServer) DeleteMessage(ctx context.Context, req *pb.MessageIdRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+
+	m, group, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if m.Deleted() {
+		return &pb.MessageActionResponse{Ok: false, Message: database.ErrMessageDeleted.Error()}, nil
+	}
+	if m.FromUser != caller {
+		if group == nil {
+			return &pb.MessageActionResponse{Ok: false, Message: "only the author can delete a private message"}, nil
+		}
+		if err := checkGroupRole(group, caller, database.GroupRoleAdmin); err != nil {
+			return &pb.MessageActionResponse{Ok: false, Message: "only the author or a group admin can delete a message"}, nil
+		}
+	}
+
+	m, err = db.DeleteMessage(m.ID, caller)
+	if err != nil {
+		if errors.Is(err, database.ErrMessageNotFound) || errors.Is(err, database.ErrMessageDeleted) {
+			return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error deleting message %d: %v", req.MessageId, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to delete message"}, nil
+	}
+
+	s.pushMessageEvent(m, group, messageEvent(m, "delete", caller))
+	return &pb.MessageActionResponse{Ok: true, Message: "message deleted"}, nil
+}
+
+// GetMessageEdits - Xem các phiên bản trước của message
+func (s *chatServer) GetMessageEdits(ctx context.Context, req *pb.MessageIdRequest) (*pb.MessageEditsResponse, error) {
+	m, _, err := s.loadMessage(req.MessageId, callerName(ctx))
+	if err != nil {
+		return &pb.MessageEditsResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	edits, err := db.GetMessageEdits(m.ID)
+	if err != nil {
+		log.Printf("Error loading edits of message %d: %v", m.ID, err)
+		return &pb.MessageEditsResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.MessageEditsResponse{Ok: true, Message: fmt.Sprintf("%d edits", len(edits))}
+	for _, e := range edits {
+		resp.Edits = append(resp.Edits, &pb.MessageEditInfo{
+			OldText:  e.OldText,
+			EditedBy: e.EditedBy,
+			EditedAt: e.EditedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
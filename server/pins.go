@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// maxPinsPerConversation giới hạn số message được ghim trong một conversation
+const maxPinsPerConversation = 25
+
+// checkPinPermission: trong nhóm cần role admin, chat riêng thì cả hai bên đều được
+func checkPinPermission(group *database.Group, caller string) error {
+	if group == nil {
+		return nil
+	}
+	if err := checkGroupRole(group, caller, database.GroupRoleAdmin); err != nil {
+		return fmt.Errorf("only group admins can pin messages in %s", group.Name)
+	}
+	return nil
+}
+
+// PinMessage - Ghim message trong conversation của nó
+func (s *chatServer) PinMessage(ctx context.Context, req *pb.MessageIdRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+
+	m, group, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if err := checkPinPermission(group, caller); err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.PinMessage(m, caller, maxPinsPerConversation); err != nil {
+		switch {
+		case errors.Is(err, database.ErrPinLimit):
+			return &pb.MessageActionResponse{Ok: false, Message: fmt.Sprintf("at most %d pinned messages per conversation", maxPinsPerConversation)}, nil
+		case errors.Is(err, database.ErrAlreadyPinned), errors.Is(err, database.ErrMessageNotFound), errors.Is(err, database.ErrMessageDeleted):
+			return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error pinning message %d: %v", m.ID, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to pin message"}, nil
+	}
+
+	s.pushMessageEvent(m, group, messageEvent(m, "pin", caller))
+	return &pb.MessageActionResponse{Ok: true, Message: "message pinned"}, nil
+}
+
+// UnpinMessage - Bỏ ghim message
+func (s *chatServer) UnpinMessage(ctx context.Context, req *pb.MessageIdRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+
+	m, group, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if err := checkPinPermission(group, caller); err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	if err := db.UnpinMessage(m.ID); err != nil {
+		if errors.Is(err, database.ErrNotPinned) {
+			return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error unpinning message %d: %v", m.ID, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to unpin message"}, nil
+	}
+
+	s.pushMessageEvent(m, group, messageEvent(m, "unpin", caller))
+	return &pb.MessageActionResponse{Ok: true, Message: "message unpinned"}, nil
+}
+
+// ListPins - Các message đang ghim của một conversation, ghim gần nhất trước
+func (s *chatServer) ListPins(ctx context.Context, req *pb.ListPinsRequest) (*pb.ListPinsResponse, error) {
+	caller := callerName(ctx)
+
+	var conversation string
+	switch req.ChatType {
+	case "group":
+		// Như history: nhóm public đọc được thì xem được pin
+		group, err := s.groupForReading(req.GroupId, req.Target, caller)
+		if err != nil {
+			return &pb.ListPinsResponse{Ok: false, Message: err.Error()}, nil
+		}
+		conversation = database.PinConversation(group.ID, "", "")
+	case "private":
+		if req.Target == "" || req.Target == caller {
+			return &pb.ListPinsResponse{Ok: false, Message: "invalid conversation"}, nil
+		}
+		conversation = database.PinConversation(0, caller, req.Target)
+	default:
+		return &pb.ListPinsResponse{Ok: false, Message: "chat_type must be private or group"}, nil
+	}
+
+	pins, err := db.ListPins(conversation)
+	if err != nil {
+		log.Printf("Error listing pins of %s: %v", conversation, err)
+		return &pb.ListPinsResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.ListPinsResponse{Ok: true, Message: fmt.Sprintf("%d pinned", len(pins))}
+	messages := make([]*pb.ChatMessage, 0, len(pins))
+	for i := range pins {
+		m := toChatMessage(&pins[i].Message)
+		messages = append(messages, m)
+		resp.Pins = append(resp.Pins, &pb.PinnedMessage{Message: m, PinnedBy: pins[i].PinnedBy, PinnedAt: pins[i].PinnedAt.Unix()})
+	}
+	attachReactions(messages, caller)
+	attachFiles(messages)
+	return resp, nil
+}
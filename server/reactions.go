@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const maxEmojiLength = 32
+
+// validateEmoji accepts an emoji or a short code like :+1:, without whitespace
+func validateEmoji(emoji string) error {
+	if emoji == "" || len(emoji) > maxEmojiLength || !utf8.ValidString(emoji) {
+		return errors.New("invalid emoji")
+	}
+	if strings.IndexFunc(emoji, unicode.IsSpace) >= 0 {
+		return errors.New("invalid emoji")
+	}
+	return nil
+}
+
+// toReactionCounts converts the aggregated reactions of one message
+func toReactionCounts(counts []database.ReactionCount) []*pb.ReactionCount {
+	var out []*pb.ReactionCount
+	for _, c := range counts {
+		out = append(out, &pb.ReactionCount{Emoji: c.Emoji, Count: int32(c.Count), Me: c.Mine})
+	}
+	return out
+}
+
+// attachReactions điền reactions cho các message trả về, một query cho cả trang
+func attachReactions(messages []*pb.ChatMessage, viewer string) {
+	ids := make([]uint, 0, len(messages))
+	for _, m := range messages {
+		if m.Id != 0 && !m.Deleted {
+			ids = append(ids, uint(m.Id))
+		}
+	}
+	counts, err := db.GetReactionCounts(ids, viewer)
+	if err != nil {
+		log.Printf("Error loading reactions: %v", err)
+		return
+	}
+	for _, m := range messages {
+		m.Reactions = toReactionCounts(counts[uint(m.Id)])
+	}
+}
+
+// react thêm hoặc bỏ reaction rồi gửi event kèm số reaction mới tới người đang online
+func (s *chatServer) react(ctx context.Context, req *pb.ReactionRequest, add bool) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+	emoji := strings.TrimSpace(req.Emoji)
+	if err := validateEmoji(emoji); err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+
+	// Chỉ cần quyền đọc: member của channel vẫn react được
+	m, group, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if m.Deleted() {
+		return &pb.MessageActionResponse{Ok: false, Message: database.ErrMessageDeleted.Error()}, nil
+	}
+
+	eventType, changed := "react", false
+	if add {
+		changed, err = db.AddReaction(m.ID, caller, emoji)
+	} else {
+		eventType = "unreact"
+		changed, err = db.RemoveReaction(m.ID, caller, emoji)
+	}
+	if err != nil {
+		log.Printf("Error updating reaction of %s on message %d: %v", caller, m.ID, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to update reaction"}, nil
+	}
+	if !changed {
+		if add {
+			return &pb.MessageActionResponse{Ok: true, Message: "already reacted"}, nil
+		}
+		return &pb.MessageActionResponse{Ok: false, Message: "no such reaction"}, nil
+	}
+
+	counts, err := db.GetReactionCounts([]uint{m.ID}, "")
+	if err != nil {
+		log.Printf("Error loading reactions of message %d: %v", m.ID, err)
+	}
+	ev := messageEvent(m, eventType, caller)
+	ev.Text = emoji
+	ev.Reactions = toReactionCounts(counts[m.ID])
+	s.pushMessageEvent(m, group, ev)
+
+	if add {
+		return &pb.MessageActionResponse{Ok: true, Message: "reaction added"}, nil
+	}
+	return &pb.MessageActionResponse{Ok: true, Message: "reaction removed"}, nil
+}
+
+// AddReaction - React message bằng emoji
+func (s *chatServer) AddReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.MessageActionResponse, error) {
+	return s.react(ctx, req, true)
+}
+
+// RemoveReaction - Bỏ reaction đã thêm
+func (s *chatServer) RemoveReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.MessageActionResponse, error) {
+	return s.react(ctx, req, false)
+}
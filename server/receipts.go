@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+// resolveConversation finds the group or the peer of a conversation of caller;
+// read cursors of groups are kept for members only
+func (s *chatServer) resolveConversation(caller, chatType, target string, groupID int64) (*database.Group, string, error) {
+	switch chatType {
+	case "group":
+		group, err := s.groupForReading(groupID, target, caller)
+		if err != nil {
+			return nil, "", err
+		}
+		member, err := db.IsGroupMember(group.ID, caller)
+		if err != nil {
+			log.Printf("Error checking membership of %s in %s: %v", caller, group.Name, err)
+			return nil, "", errors.New("database error")
+		}
+		if !member {
+			return nil, "", fmt.Errorf("you are not a member of %s", group.Name)
+		}
+		return group, "", nil
+	case "private":
+		if target == "" || target == caller {
+			return nil, "", errors.New("invalid conversation")
+		}
+		return nil, target, nil
+	}
+	return nil, "", errors.New("chat_type must be private or group")
+}
+
+// markRead dời read cursor và gửi read receipt nếu user bật
+func (s *chatServer) markRead(caller string, req *pb.MarkReadRequest) (string, error) {
+	group, peer, err := s.resolveConversation(caller, req.ChatType, req.Target, req.GroupId)
+	if err != nil {
+		return "", err
+	}
+
+	messageID := uint(req.MessageId)
+	if messageID == 0 {
+		if group != nil {
+			messageID, err = db.LatestGroupMessageID(group.ID)
+		} else {
+			messageID, err = db.LatestPrivateMessageID(caller, peer)
+		}
+		if err != nil {
+			log.Printf("Error loading latest message for %s: %v", caller, err)
+			return "", errors.New("database error")
+		}
+		if messageID == 0 {
+			return "nothing to read", nil
+		}
+	} else {
+		// Message phải thuộc conversation
+		m, err := db.GetMessage(messageID)
+		if err != nil {
+			return "", database.ErrMessageNotFound
+		}
+		inGroup := group != nil && m.GroupID != nil && *m.GroupID == group.ID
+		inPrivate := group == nil && m.GroupID == nil &&
+			((m.FromUser == caller && m.ToTarget == peer) || (m.FromUser == peer && m.ToTarget == caller))
+		if !inGroup && !inPrivate {
+			return "", database.ErrMessageNotFound
+		}
+	}
+
+	var groupID uint
+	if group != nil {
+		groupID = group.ID
+	}
+	advanced, err := db.MarkRead(caller, groupID, peer, messageID)
+	if err != nil {
+		log.Printf("Error marking read for %s: %v", caller, err)
+		return "", errors.New("failed to mark read")
+	}
+	if !advanced {
+		return "already read", nil
+	}
+	// Tin tự hủy kiểu "read" bắt đầu đếm khi có người đọc; client cần expires_at để tự ẩn
+	started, err := db.StartReadExpiry(caller, groupID, peer, messageID)
+	if err != nil {
+		log.Printf("Error starting read expiry for %s: %v", caller, err)
+	}
+	for i := range started {
+		ev := messageEvent(&started[i], "expire", caller)
+		ev.Text, ev.Mentions = "", nil
+		s.pushMessageEvent(&started[i], group, ev)
+	}
+
+	user, err := db.GetUserByUsername(caller)
+	if err != nil || !user.ReadReceipts {
+		return "marked as read", nil
+	}
+	ev := &pb.ChatMessage{
+		From:      caller,
+		Type:      "read",
+		Id:        int64(messageID),
+		Timestamp: time.Now().Unix(),
+		ChatType:  req.ChatType,
+	}
+	if group != nil {
+		ev.To = group.Name
+		ev.GroupId = int64(group.ID)
+		s.fanoutGroup(group, ev, caller)
+	} else {
+		ev.To = peer
+		s.mu.RLock()
+		c, ok := s.clients[peer]
+		s.mu.RUnlock()
+		if ok {
+			select {
+			case c.send <- ev:
+			default:
+				log.Printf("user %s buffer full, dropping read receipt", peer)
+			}
+		}
+	}
+	return "marked as read", nil
+}
+
+// MarkRead - Đánh dấu đã đọc tới một message (0 = mới nhất) của conversation
+func (s *chatServer) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MessageActionResponse, error) {
+	msg, err := s.markRead(callerName(ctx), req)
+	if err != nil {
+		return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	return &pb.MessageActionResponse{Ok: true, Message: msg}, nil
+}
+
+// UpdateSettings - Đổi settings của user (read receipts); không truyền field nào thì trả về settings hiện tại
+func (s *chatServer) UpdateSettings(ctx context.Context, req *pb.UpdateSettingsRequest) (*pb.SettingsResponse, error) {
+	caller := callerName(ctx)
+
+	if req.ReadReceipts != nil {
+		if err := db.SetReadReceipts(caller, *req.ReadReceipts); err != nil {
+			log.Printf("Error updating settings of %s: %v", caller, err)
+			return &pb.SettingsResponse{Ok: false, Message: "failed to update settings"}, nil
+		}
+		log.Printf("User %s set read receipts to %v", caller, *req.ReadReceipts)
+	}
+
+	user, err := db.GetUserByUsername(caller)
+	if err != nil {
+		return &pb.SettingsResponse{Ok: false, Message: "database error"}, nil
+	}
+	return &pb.SettingsResponse{Ok: true, Message: "settings saved", ReadReceipts: user.ReadReceipts}, nil
+}
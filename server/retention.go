@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"gorm.io/gorm"
+)
+
+const (
+	retentionBatch  = 500
+	retentionPause  = 100 * time.Millisecond // giữa các batch, để purge nhường cho chat
+	maxRetainDays   = 36500
+	partitionsAhead = 3 // months of messages partitions created in advance
+)
+
+// archivedMessage is one line of a message archive
+type archivedMessage struct {
+	ID          uint                     `json:"id"`
+	FromUser    string                   `json:"from_user"`
+	ToTarget    string                   `json:"to_target"`
+	GroupID     *uint                    `json:"group_id,omitempty"`
+	MessageType string                   `json:"message_type"`
+	Text        string                   `json:"text"`
+	CreatedAt   time.Time                `json:"created_at"`
+	EditedAt    *time.Time               `json:"edited_at,omitempty"`
+	DeletedAt   *time.Time               `json:"deleted_at,omitempty"`
+	DeletedBy   string                   `json:"deleted_by,omitempty"`
+	ReplyTo     *uint                    `json:"reply_to,omitempty"`
+	ThreadRoot  *uint                    `json:"thread_root,omitempty"`
+	Mentions    []database.MentionEntity `json:"mentions,omitempty"`
+}
+
+// archiveMessages ghi message vào file gzip JSONL theo tháng gửi, vd. messages-2026-10.jsonl.gz.
+// Mỗi lần ghi thêm một gzip member vào cuối file; zcat và gzip.Reader đọc được cả file.
+func archiveMessages(dir string, messages []database.Message) error {
+	byMonth := make(map[string][]database.Message)
+	for _, m := range messages {
+		month := m.CreatedAt.UTC().Format("2006-01")
+		byMonth[month] = append(byMonth[month], m)
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	for month, batch := range byMonth {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		enc := json.NewEncoder(gz)
+		for _, m := range batch {
+			if err := enc.Encode(archivedMessage{
+				ID: m.ID, FromUser: m.FromUser, ToTarget: m.ToTarget, GroupID: m.GroupID, MessageType: m.MessageType,
+				Text: m.Text, CreatedAt: m.CreatedAt, EditedAt: m.EditedAt, DeletedAt: m.DeletedAt, DeletedBy: m.DeletedBy,
+				ReplyTo: m.ReplyTo, ThreadRoot: m.ThreadRoot, Mentions: m.Mentions,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+
+		// Ghi cả member một lần để các instance cùng append không xen nhau
+		f, err := os.OpenFile(filepath.Join(dir, "messages-"+month+".jsonl.gz"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(buf.Bytes()); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRetention chạy retention theo chu kỳ
+func runRetention(interval time.Duration, archiveDir string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		applyRetention(time.Now(), archiveDir)
+	}
+}
+
+// applyRetention purge message theo từng policy rồi bảo trì partition của messages
+func applyRetention(now time.Time, archiveDir string) {
+	policies, err := db.ListRetentionPolicies()
+	if err != nil {
+		log.Printf("Error loading retention policies: %v", err)
+		return
+	}
+
+	for i := range policies {
+		p := &policies[i]
+		var archive func([]database.Message) error
+		if p.Archive {
+			// Policy muốn archive nhưng server tắt archive: giữ message lại
+			if archiveDir == "" {
+				log.Printf("Skipping retention policy %d: it archives but -archive-dir is empty", p.ID)
+				continue
+			}
+			archive = func(messages []database.Message) error {
+				return archiveMessages(archiveDir, messages)
+			}
+		}
+
+		total := 0
+		for {
+			n, err := db.PurgeRetentionBatch(p, now, retentionBatch, archive)
+			if err != nil {
+				log.Printf("Error applying retention policy %d: %v", p.ID, err)
+				break
+			}
+			total += n
+			if n < retentionBatch {
+				break
+			}
+			time.Sleep(retentionPause)
+		}
+		if total > 0 {
+			log.Printf("Retention purged %d messages older than %d days (type %q, group %d, archived %t)",
+				total, p.RetainDays, p.MessageType, p.GroupID, p.Archive)
+		}
+	}
+
+	partitioned, err := db.MessagesPartitioned()
+	if err != nil || !partitioned {
+		return
+	}
+	dropped, err := db.MaintainMessagePartitions(now, partitionsAhead)
+	if err != nil {
+		log.Printf("Error maintaining messages partitions: %v", err)
+	}
+	for _, name := range dropped {
+		log.Printf("Dropped empty messages partition %s", name)
+	}
+}
+
+// SetRetentionPolicy - Đặt / bỏ retention cho một loại message hoặc một group
+func (a *adminServer) SetRetentionPolicy(ctx context.Context, req *pb.SetRetentionPolicyRequest) (*pb.AdminResponse, error) {
+	auth := authFromContext(ctx)
+	if (req.MessageType == "") == (req.GroupName == "") {
+		return &pb.AdminResponse{Ok: false, Message: "one of message_type or group_name is required"}, nil
+	}
+	if req.RetainDays < 0 || req.RetainDays > maxRetainDays {
+		return &pb.AdminResponse{Ok: false, Message: fmt.Sprintf("retain_days must be between 1 and %d, or 0 to remove", maxRetainDays)}, nil
+	}
+
+	policy := &database.RetentionPolicy{MessageType: req.MessageType, RetainDays: int(req.RetainDays), Archive: req.Archive, UpdatedBy: auth.username}
+	target := "type " + req.MessageType
+	if req.GroupName != "" {
+		group, err := db.GetGroupByName(req.GroupName, "")
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &pb.AdminResponse{Ok: false, Message: "group not found"}, nil
+			}
+			if errors.Is(err, database.ErrAmbiguousGroup) {
+				return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+			}
+			log.Printf("Error loading group %s: %v", req.GroupName, err)
+			return &pb.AdminResponse{Ok: false, Message: "database error"}, nil
+		}
+		policy.GroupID = group.ID
+		target = "group " + group.Name
+	} else if req.MessageType != "private" && req.MessageType != "group" && req.MessageType != "system" {
+		return &pb.AdminResponse{Ok: false, Message: "message_type must be private, group or system"}, nil
+	}
+
+	if req.RetainDays == 0 {
+		if err := db.DeleteRetentionPolicy(policy.MessageType, policy.GroupID); err != nil {
+			if errors.Is(err, database.ErrRetentionNotFound) {
+				return &pb.AdminResponse{Ok: false, Message: err.Error()}, nil
+			}
+			log.Printf("Error removing retention policy of %s: %v", target, err)
+			return &pb.AdminResponse{Ok: false, Message: "failed to remove retention policy"}, nil
+		}
+		recordAudit(auth.username, "remove_retention", target, req.Reason)
+		log.Printf("Retention policy of %s removed by %s", target, auth.username)
+		return &pb.AdminResponse{Ok: true, Message: "retention policy of " + target + " removed"}, nil
+	}
+
+	if err := db.SetRetentionPolicy(policy); err != nil {
+		log.Printf("Error setting retention policy of %s: %v", target, err)
+		return &pb.AdminResponse{Ok: false, Message: "failed to set retention policy"}, nil
+	}
+	details := fmt.Sprintf("days=%d archive=%t: %s", req.RetainDays, req.Archive, req.Reason)
+	recordAudit(auth.username, "set_retention", target, details)
+	log.Printf("Retention policy of %s set by %s (%s)", target, auth.username, details)
+	return &pb.AdminResponse{Ok: true, Message: fmt.Sprintf("messages of %s are kept %d days", target, req.RetainDays)}, nil
+}
+
+// ListRetentionPolicies - Xem các retention policy
+func (a *adminServer) ListRetentionPolicies(ctx context.Context, _ *pb.Empty) (*pb.ListRetentionPoliciesResponse, error) {
+	resp := &pb.ListRetentionPoliciesResponse{}
+
+	policies, err := db.ListRetentionPolicies()
+	if err != nil {
+		log.Printf("Error listing retention policies: %v", err)
+		return resp, nil
+	}
+	for _, p := range policies {
+		info := &pb.RetentionPolicyInfo{
+			MessageType: p.MessageType,
+			GroupId:     int64(p.GroupID),
+			RetainDays:  int32(p.RetainDays),
+			Archive:     p.Archive,
+			UpdatedBy:   p.UpdatedBy,
+			UpdatedAt:   p.UpdatedAt.Unix(),
+		}
+		if p.GroupID != 0 {
+			if group, err := db.GetGroupByID(p.GroupID); err == nil {
+				info.GroupName = group.Name
+			}
+		}
+		resp.Policies = append(resp.Policies, info)
+	}
+	if resp.Partitioned, err = db.MessagesPartitioned(); err != nil {
+		log.Printf("Error checking messages partitioning: %v", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	maxScheduleAhead    = 365 * 24 * time.Hour
+	maxPendingScheduled = 100 // per user
+	schedulerInterval   = 5 * time.Second
+	schedulerBatch      = 50
+	staleScheduleClaim  = 5 * time.Minute // claimed but never finished: the instance stopped while sending
+)
+
+// toScheduledInfo converts a stored scheduled message
+func toScheduledInfo(sm *database.ScheduledMessage) *pb.ScheduledMessageInfo {
+	info := &pb.ScheduledMessageInfo{
+		Id:        int64(sm.ID),
+		ChatType:  sm.ChatType,
+		Target:    sm.Target,
+		Text:      sm.Text,
+		SendAt:    sm.SendAt.Unix(),
+		Status:    sm.Status,
+		CreatedAt: sm.CreatedAt.Unix(),
+	}
+	if sm.GroupID != nil {
+		info.GroupId = int64(*sm.GroupID)
+	}
+	return info
+}
+
+// ScheduleMessage - Hẹn giờ gửi message. Quyền gửi được kiểm tra lúc hẹn và lại lúc gửi.
+func (s *chatServer) ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ScheduleMessageResponse, error) {
+	caller := callerName(ctx)
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "empty message"}, nil
+	}
+	now := time.Now()
+	sendAt := time.Unix(req.SendAt, 0)
+	if !sendAt.After(now) {
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "send time must be in the future"}, nil
+	}
+	if sendAt.After(now.Add(maxScheduleAhead)) {
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "send time must be within one year"}, nil
+	}
+
+	pending, err := db.CountPendingScheduled(caller)
+	if err != nil {
+		log.Printf("Error counting scheduled messages of %s: %v", caller, err)
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "database error"}, nil
+	}
+	if pending >= maxPendingScheduled {
+		return &pb.ScheduleMessageResponse{Ok: false, Message: fmt.Sprintf("at most %d pending scheduled messages", maxPendingScheduled)}, nil
+	}
+
+	sm := &database.ScheduledMessage{Username: caller, ChatType: req.ChatType, Text: text, SendAt: sendAt}
+	switch req.ChatType {
+	case "group":
+		group, err := s.groupForPosting(req.GroupId, req.Target, caller)
+		if err != nil {
+			return &pb.ScheduleMessageResponse{Ok: false, Message: err.Error()}, nil
+		}
+		sm.Target, sm.GroupID = group.Name, &group.ID
+	case "private":
+		if req.Target == "" || req.Target == caller {
+			return &pb.ScheduleMessageResponse{Ok: false, Message: "invalid conversation"}, nil
+		}
+		shared, err := db.SharesWorkspace(caller, req.Target)
+		if err != nil {
+			log.Printf("Error checking workspaces of %s and %s: %v", caller, req.Target, err)
+			return &pb.ScheduleMessageResponse{Ok: false, Message: "database error"}, nil
+		}
+		if !shared {
+			return &pb.ScheduleMessageResponse{Ok: false, Message: fmt.Sprintf("you do not share a workspace with %s", req.Target)}, nil
+		}
+		sm.Target = req.Target
+	default:
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "chat_type must be private or group"}, nil
+	}
+
+	if err := db.CreateScheduledMessage(sm); err != nil {
+		log.Printf("Error scheduling message of %s: %v", caller, err)
+		return &pb.ScheduleMessageResponse{Ok: false, Message: "failed to schedule message"}, nil
+	}
+	log.Printf("%s scheduled message %d to %s at %s", caller, sm.ID, sm.Target, sendAt.Format(time.RFC3339))
+	return &pb.ScheduleMessageResponse{
+		Ok:        true,
+		Message:   fmt.Sprintf("message #%d scheduled for %s", sm.ID, sendAt.Format("2006-01-02 15:04")),
+		Scheduled: toScheduledInfo(sm),
+	}, nil
+}
+
+// ListScheduledMessages - Các message đang chờ gửi của user
+func (s *chatServer) ListScheduledMessages(ctx context.Context, _ *pb.Empty) (*pb.ListScheduledMessagesResponse, error) {
+	caller := callerName(ctx)
+	resp := &pb.ListScheduledMessagesResponse{}
+
+	scheduled, err := db.ListScheduledMessages(caller)
+	if err != nil {
+		log.Printf("Error listing scheduled messages of %s: %v", caller, err)
+		return resp, nil
+	}
+	for i := range scheduled {
+		resp.Scheduled = append(resp.Scheduled, toScheduledInfo(&scheduled[i]))
+	}
+	return resp, nil
+}
+
+// CancelScheduledMessage - Hủy message đang chờ gửi
+func (s *chatServer) CancelScheduledMessage(ctx context.Context, req *pb.CancelScheduledMessageRequest) (*pb.MessageActionResponse, error) {
+	caller := callerName(ctx)
+
+	if err := db.CancelScheduledMessage(uint(req.ScheduleId), caller); err != nil {
+		if errors.Is(err, database.ErrScheduleNotFound) {
+			return &pb.MessageActionResponse{Ok: false, Message: err.Error()}, nil
+		}
+		log.Printf("Error canceling scheduled message %d: %v", req.ScheduleId, err)
+		return &pb.MessageActionResponse{Ok: false, Message: "failed to cancel scheduled message"}, nil
+	}
+	return &pb.MessageActionResponse{Ok: true, Message: fmt.Sprintf("scheduled message #%d canceled", req.ScheduleId)}, nil
+}
+
+// runScheduler gửi các scheduled message đến hạn. Mọi instance của server đều chạy;
+// mỗi message chỉ được một instance claim nên không bị gửi hai lần, và vì trạng thái
+// nằm trong database nên message hẹn trước khi restart vẫn được gửi.
+func (s *chatServer) runScheduler() {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if n, err := db.FailStaleScheduled(time.Now().Add(-staleScheduleClaim)); err != nil {
+			log.Printf("Error failing stale scheduled messages: %v", err)
+		} else if n > 0 {
+			log.Printf("Marked %d interrupted scheduled messages as failed", n)
+		}
+
+		for {
+			due, err := db.ClaimDueScheduled(time.Now(), schedulerBatch)
+			if err != nil {
+				log.Printf("Error claiming scheduled messages: %v", err)
+				break
+			}
+			for i := range due {
+				s.dispatchScheduled(&due[i])
+			}
+			if len(due) < schedulerBatch {
+				break
+			}
+		}
+	}
+}
+
+// dispatchScheduled gửi một scheduled message qua cùng đường với message từ stream
+func (s *chatServer) dispatchScheduled(sm *database.ScheduledMessage) {
+	reason := ""
+	msg := &pb.ChatMessage{From: sm.Username, To: sm.Target, Type: sm.ChatType, Text: sm.Text, Timestamp: time.Now().Unix()}
+	if sm.GroupID != nil {
+		msg.GroupId = int64(*sm.GroupID)
+	}
+
+	user, err := db.GetUserByUsername(sm.Username)
+	switch {
+	case err != nil:
+		log.Printf("Error loading sender of scheduled message %d: %v", sm.ID, err)
+		reason = "sender not found"
+	case user.DisabledAt != nil:
+		reason = "account disabled"
+	default:
+		// handleIncoming kiểm tra quyền lại và báo lỗi cho người gửi nếu bị từ chối
+		if !s.handleIncoming(msg) {
+			reason = "message was rejected"
+		}
+	}
+
+	if err := db.FinishScheduled(sm.ID, uint(msg.Id), reason); err != nil {
+		log.Printf("Error finishing scheduled message %d: %v", sm.ID, err)
+	}
+	if reason != "" {
+		log.Printf("Scheduled message %d of %s failed: %s", sm.ID, sm.Username, reason)
+		s.notify(sm.Username, "notice", sm.Target, fmt.Sprintf("scheduled message #%d was not sent: %s", sm.ID, reason))
+		return
+	}
+	log.Printf("Sent scheduled message %d of %s as message %d", sm.ID, sm.Username, msg.Id)
+	text := fmt.Sprintf("scheduled message #%d sent as #%d", sm.ID, msg.Id)
+	if msg.Id == 0 {
+		text = fmt.Sprintf("scheduled message #%d sent", sm.ID)
+	}
+	s.notify(sm.Username, "notice", sm.Target, text)
+}
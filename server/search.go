@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	maxSearchQuery     = 200
+)
+
+// SearchMessages - Tìm kiếm full-text trong các conversation user được đọc,
+// lọc theo người gửi, conversation và khoảng thời gian
+func (s *chatServer) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	caller := callerName(ctx)
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return &pb.SearchMessagesResponse{Ok: false, Message: "search query is required"}, nil
+	}
+	if len(query) > maxSearchQuery {
+		return &pb.SearchMessagesResponse{Ok: false, Message: "search query is too long"}, nil
+	}
+
+	search := database.MessageSearch{
+		Viewer: caller,
+		Query:  query,
+		From:   strings.TrimSpace(req.From),
+		Offset: max(int(req.Offset), 0),
+		Limit:  int(req.Limit),
+	}
+	if search.Limit <= 0 {
+		search.Limit = defaultSearchLimit
+	}
+	if search.Limit > maxSearchLimit {
+		search.Limit = maxSearchLimit
+	}
+
+	// Lọc theo conversation: nhóm public cũng tìm được như khi xem history
+	switch req.ChatType {
+	case "":
+	case "group":
+		group, err := s.groupForReading(req.GroupId, req.Target, caller)
+		if err != nil {
+			return &pb.SearchMessagesResponse{Ok: false, Message: err.Error()}, nil
+		}
+		search.GroupID = group.ID
+	case "private":
+		if req.Target == "" || req.Target == caller {
+			return &pb.SearchMessagesResponse{Ok: false, Message: "invalid conversation"}, nil
+		}
+		search.Peer = req.Target
+	default:
+		return &pb.SearchMessagesResponse{Ok: false, Message: "chat_type must be private or group"}, nil
+	}
+
+	if req.After > 0 {
+		t := time.Unix(req.After, 0)
+		search.After = &t
+	}
+	if req.Before > 0 {
+		t := time.Unix(req.Before, 0)
+		search.Before = &t
+	}
+	if search.After != nil && search.Before != nil && !search.After.Before(*search.Before) {
+		return &pb.SearchMessagesResponse{Ok: false, Message: "after must be earlier than before"}, nil
+	}
+
+	// Lấy thêm một dòng để biết còn trang sau không
+	limit := search.Limit
+	search.Limit++
+	hits, err := db.SearchMessages(search)
+	if err != nil {
+		log.Printf("Error searching messages for %s: %v", caller, err)
+		return &pb.SearchMessagesResponse{Ok: false, Message: "search failed"}, nil
+	}
+
+	resp := &pb.SearchMessagesResponse{Ok: true}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		resp.NextOffset = int32(search.Offset + limit)
+	}
+
+	messages := make([]*pb.ChatMessage, 0, len(hits))
+	for i := range hits {
+		m := toChatMessage(&hits[i].Message)
+		messages = append(messages, m)
+		resp.Results = append(resp.Results, &pb.MessageSearchResult{Message: m, Snippet: hits[i].Snippet})
+	}
+	attachReactions(messages, caller)
+	attachFiles(messages)
+	return resp, nil
+}
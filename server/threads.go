@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	defaultThreadLimit = 50
+	maxThreadLimit     = 200
+)
+
+// replyParent loads the message msg answers and points msg at its conversation;
+// type / to / group_id sent by the client are ignored for replies
+func (s *chatServer) replyParent(msg *pb.ChatMessage) (*database.Message, error) {
+	parent, _, err := s.loadMessage(msg.ReplyTo, msg.From)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Deleted() {
+		return nil, errors.New("cannot reply to a deleted message")
+	}
+
+	if parent.GroupID != nil {
+		msg.Type = "group"
+		msg.GroupId = int64(*parent.GroupID)
+		return parent, nil
+	}
+	msg.Type = "private"
+	msg.GroupId = 0
+	msg.To = parent.ToTarget
+	if parent.ToTarget == msg.From {
+		msg.To = parent.FromUser
+	}
+	return parent, nil
+}
+
+// saveReply lưu reply và điền thread metadata vào msg
+func saveReply(msg *pb.ChatMessage, parent *database.Message, group *database.Group) (*database.Message, error) {
+	reply := &database.Message{
+		FromUser:    msg.From,
+		ToTarget:    msg.To,
+		MessageType: msg.Type,
+		Text:        msg.Text,
+	}
+	if group != nil {
+		reply.GroupID = &group.ID
+	}
+	if err := db.SaveReply(reply, parent); err != nil {
+		return nil, err
+	}
+	msg.ThreadRoot = int64(*reply.ThreadRoot)
+	return reply, nil
+}
+
+// GetThread - Lấy message gốc và các reply của thread, phân trang theo after_id
+func (s *chatServer) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.GetThreadResponse, error) {
+	caller := callerName(ctx)
+
+	m, _, err := s.loadMessage(req.MessageId, caller)
+	if err != nil {
+		return &pb.GetThreadResponse{Ok: false, Message: err.Error()}, nil
+	}
+	root := m
+	if m.ThreadRoot != nil {
+		if root, err = db.GetMessage(*m.ThreadRoot); err != nil {
+			log.Printf("Error loading thread root %d: %v", *m.ThreadRoot, err)
+			return &pb.GetThreadResponse{Ok: false, Message: "database error"}, nil
+		}
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultThreadLimit
+	}
+	if limit > maxThreadLimit {
+		limit = maxThreadLimit
+	}
+
+	// Lấy thêm một dòng để biết còn trang sau không
+	replies, err := db.GetThreadReplies(root.ID, uint(max(req.AfterId, 0)), limit+1)
+	if err != nil {
+		log.Printf("Error loading thread %d: %v", root.ID, err)
+		return &pb.GetThreadResponse{Ok: false, Message: "database error"}, nil
+	}
+
+	resp := &pb.GetThreadResponse{Ok: true, Root: toChatMessage(root)}
+	if len(replies) > limit {
+		replies = replies[:limit]
+		resp.NextAfterId = int64(replies[limit-1].ID)
+	}
+	for i := range replies {
+		resp.Replies = append(resp.Replies, toChatMessage(&replies[i]))
+	}
+	all := append([]*pb.ChatMessage{resp.Root}, resp.Replies...)
+	attachReactions(all, caller)
+	attachFiles(all)
+	return resp, nil
+}
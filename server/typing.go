@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+)
+
+const (
+	typingTimeout  = 6 * time.Second  // không nhận được stop thì server tự gửi stop
+	typingThrottle = 3 * time.Second  // start lặp lại trong khoảng này chỉ gia hạn, không gửi lại
+	typingWindow   = 10 * time.Second // cửa sổ rate limit mỗi user
+	typingBurst    = 20               // số event typing tối đa mỗi user trong một cửa sổ
+)
+
+// typingKey identifies one user typing in one conversation
+type typingKey struct {
+	username     string
+	conversation string
+}
+
+func privateConversation(username string) string { return "@" + username }
+
+func groupConversation(groupID uint) string { return fmt.Sprintf("#%d", groupID) }
+
+// typingState is an active indicator with where to route its events
+type typingState struct {
+	group    *database.Group // nil for private conversations
+	to       string
+	lastSent time.Time
+	timer    *time.Timer
+}
+
+// typingRate counts typing events of a user in the current window
+type typingRate struct {
+	start time.Time
+	count int
+}
+
+// typingTracker holds the typing indicators in memory; nothing is persisted
+type typingTracker struct {
+	mu     sync.Mutex
+	active map[typingKey]*typingState
+	rates  map[string]*typingRate
+}
+
+func newTypingTracker() *typingTracker {
+	return &typingTracker{
+		active: make(map[typingKey]*typingState),
+		rates:  make(map[string]*typingRate),
+	}
+}
+
+// allow applies the per-user rate limit; events over the limit are dropped
+func (t *typingTracker) allow(username string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r, ok := t.rates[username]
+	if !ok || now.Sub(r.start) >= typingWindow {
+		t.rates[username] = &typingRate{start: now, count: 1}
+		return true
+	}
+	r.count++
+	return r.count <= typingBurst
+}
+
+// clear removes an indicator without notifying anyone, e.g. when the message was sent
+func (t *typingTracker) clear(username, conversation string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := typingKey{username, conversation}
+	if st, ok := t.active[key]; ok {
+		st.timer.Stop()
+		delete(t.active, key)
+	}
+}
+
+// take removes and returns an indicator; only the given state is removed when st is set
+func (t *typingTracker) take(key typingKey, st *typingState) *typingState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cur, ok := t.active[key]
+	if !ok || (st != nil && cur != st) {
+		return nil
+	}
+	cur.timer.Stop()
+	delete(t.active, key)
+	return cur
+}
+
+// refresh extends an indicator started less than typingThrottle ago;
+// it returns false when the start event has to be validated and sent again
+func (t *typingTracker) refresh(key typingKey, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st, ok := t.active[key]
+	if !ok || now.Sub(st.lastSent) >= typingThrottle {
+		return false
+	}
+	st.timer.Reset(typingTimeout)
+	return true
+}
+
+// start registers an indicator that calls expire when no stop or refresh arrives in time
+func (t *typingTracker) start(key typingKey, st *typingState, now time.Time, expire func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if old, ok := t.active[key]; ok {
+		old.timer.Stop()
+	}
+	st.lastSent = now
+	st.timer = time.AfterFunc(typingTimeout, expire)
+	t.active[key] = st
+}
+
+// handleTyping xử lý event typing start / stop của client
+func (s *chatServer) handleTyping(msg *pb.ChatMessage) {
+	now := time.Now()
+	if !s.typing.allow(msg.From, now) {
+		return
+	}
+
+	var key typingKey
+	switch msg.ChatType {
+	case "private":
+		key = typingKey{msg.From, privateConversation(msg.To)}
+	case "group":
+		// Client nên gửi group_id; gửi theo tên thì phải tra cứu group mỗi lần
+		if msg.GroupId == 0 {
+			group, err := loadGroup(0, msg.To, msg.From)
+			if err != nil {
+				return
+			}
+			msg.GroupId = int64(group.ID)
+		}
+		key = typingKey{msg.From, groupConversation(uint(msg.GroupId))}
+	default:
+		return
+	}
+
+	if msg.Text == "stop" {
+		if st := s.typing.take(key, nil); st != nil {
+			s.sendTyping(key.username, st, "stop")
+		}
+		return
+	}
+	if s.typing.refresh(key, now) {
+		return // start lặp lại: chỉ gia hạn
+	}
+
+	// Start mới hoặc đã quá throttle: kiểm tra quyền rồi mới gửi
+	st := &typingState{to: msg.To}
+	if msg.ChatType == "group" {
+		group, err := s.groupForPosting(msg.GroupId, "", msg.From)
+		if err != nil {
+			return
+		}
+		st.group, st.to = group, group.Name
+	} else if shared, err := db.SharesWorkspace(msg.From, msg.To); err != nil || !shared {
+		return
+	}
+
+	s.typing.start(key, st, now, func() {
+		// Hết hạn mà không nhận được stop
+		if expired := s.typing.take(key, st); expired != nil {
+			s.sendTyping(key.username, expired, "stop")
+		}
+	})
+	s.sendTyping(key.username, st, "start")
+}
+
+// sendTyping gửi event typing tới những người còn lại của conversation đang online
+func (s *chatServer) sendTyping(from string, st *typingState, state string) {
+	ev := &pb.ChatMessage{
+		From:      from,
+		To:        st.to,
+		Type:      "typing",
+		Text:      state,
+		Timestamp: time.Now().Unix(),
+		ChatType:  "private",
+	}
+	if st.group != nil {
+		ev.ChatType = "group"
+		ev.GroupId = int64(st.group.ID)
+		s.fanoutGroup(st.group, ev, from)
+		return
+	}
+
+	s.mu.RLock()
+	c, ok := s.clients[st.to]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+	select {
+	case c.send <- ev:
+	default:
+		// Typing là ephemeral, buffer đầy thì bỏ qua
+	}
+}
+
+// stopUserTyping gửi stop cho mọi indicator của user khi user ngắt kết nối
+func (s *chatServer) stopUserTyping(username string) {
+	s.typing.mu.Lock()
+	var keys []typingKey
+	for key := range s.typing.active {
+		if key.username == username {
+			keys = append(keys, key)
+		}
+	}
+	delete(s.typing.rates, username)
+	s.typing.mu.Unlock()
+
+	for _, key := range keys {
+		if st := s.typing.take(key, nil); st != nil {
+			s.sendTyping(username, st, "stop")
+		}
+	}
+	if len(keys) > 0 {
+		log.Printf("Cleared %d typing indicators of %s", len(keys), username)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"chat-grpc/database"
+	pb "chat-grpc/proto"
+
+	"gorm.io/gorm"
+)
+
+var workspaceSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,49}$`)
+
+// resolveWorkspace chọn workspace cho caller: slug chỉ định thì caller phải là member;
+// để trống thì dùng workspace duy nhất của caller, hoặc "default" nếu caller ở trong đó
+func resolveWorkspace(caller, slug string) (*database.Workspace, error) {
+	memberships, err := db.GetUserWorkspaces(caller)
+	if err != nil {
+		log.Printf("Error loading workspaces of %s: %v", caller, err)
+		return nil, errors.New("database error")
+	}
+
+	if slug == "" {
+		if len(memberships) == 1 {
+			return &memberships[0].Workspace, nil
+		}
+		slug = database.DefaultWorkspaceSlug
+	}
+	for _, m := range memberships {
+		if m.Workspace.Slug == slug {
+			return &m.Workspace, nil
+		}
+	}
+	if slug == database.DefaultWorkspaceSlug {
+		return nil, errors.New("you belong to several workspaces, specify one")
+	}
+	return nil, fmt.Errorf("you are not a member of workspace %s", slug)
+}
+
+// workspaceScope trả về các workspace ID caller được xem; slug khác rỗng thì chỉ workspace đó
+func workspaceScope(caller, slug string) ([]uint, error) {
+	if slug != "" {
+		ws, err := resolveWorkspace(caller, slug)
+		if err != nil {
+			return nil, err
+		}
+		return []uint{ws.ID}, nil
+	}
+	ids, err := db.GetUserWorkspaceIDs(caller)
+	if err != nil {
+		log.Printf("Error loading workspaces of %s: %v", caller, err)
+		return nil, errors.New("database error")
+	}
+	return ids, nil
+}
+
+// requireGroupWorkspace checks username belongs to the workspace of a group
+func requireGroupWorkspace(group *database.Group, username string) error {
+	if group.WorkspaceID == nil {
+		return nil
+	}
+	if _, err := db.GetWorkspaceRole(*group.WorkspaceID, username); err != nil {
+		if errors.Is(err, database.ErrNotWorkspaceMember) {
+			return fmt.Errorf("%s is not in the workspace of %s", username, group.Name)
+		}
+		log.Printf("Error checking workspace of %s for %s: %v", group.Name, username, err)
+		return errors.New("database error")
+	}
+	return nil
+}
+
+// workspaceSlugs maps workspace IDs to slugs for display
+func workspaceSlugs(username string) map[uint]string {
+	slugs := make(map[uint]string)
+	memberships, err := db.GetUserWorkspaces(username)
+	if err != nil {
+		log.Printf("Error loading workspaces of %s: %v", username, err)
+		return slugs
+	}
+	for _, m := range memberships {
+		slugs[m.WorkspaceID] = m.Workspace.Slug
+	}
+	return slugs
+}
+
+// requireWorkspaceAdmin loads a workspace and checks caller is its owner or admin
+func requireWorkspaceAdmin(slug, caller string) (*database.Workspace, error) {
+	ws, err := db.GetWorkspaceBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("workspace %s not found", slug)
+		}
+		return nil, errors.New("database error")
+	}
+	role, err := db.GetWorkspaceRole(ws.ID, caller)
+	if err != nil && !errors.Is(err, database.ErrNotWorkspaceMember) {
+		return nil, errors.New("database error")
+	}
+	if role != database.WorkspaceRoleOwner && role != database.WorkspaceRoleAdmin {
+		return nil, errors.New("requires workspace admin")
+	}
+	return ws, nil
+}
+
+// requireServerAdmin checks the caller has the server admin role; workspace
+// membership lifts the isolation between users, so only admins grant it
+func requireServerAdmin(ctx context.Context) error {
+	if auth := authFromContext(ctx); auth == nil || auth.role != database.RoleAdmin {
+		return errors.New("requires server admin")
+	}
+	return nil
+}
+
+// CreateWorkspace - Server admin tạo workspace mới, người tạo là owner
+func (s *chatServer) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.CreateWorkspaceResponse, error) {
+	caller := callerName(ctx)
+	if err := requireServerAdmin(ctx); err != nil {
+		return &pb.CreateWorkspaceResponse{Ok: false, Message: err.Error()}, nil
+	}
+	slug := strings.ToLower(req.Slug)
+	if !workspaceSlugPattern.MatchString(slug) {
+		return &pb.CreateWorkspaceResponse{Ok: false, Message: "slug must be 2-50 characters: lowercase letters, digits, '-'"}, nil
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = slug
+	}
+
+	if _, err := db.GetWorkspaceBySlug(slug); err == nil {
+		return &pb.CreateWorkspaceResponse{Ok: false, Message: "workspace already exists"}, nil
+	}
+
+	ws, err := db.CreateWorkspace(slug, name, caller)
+	if err != nil {
+		log.Printf("Error creating workspace %s: %v", slug, err)
+		return &pb.CreateWorkspaceResponse{Ok: false, Message: "failed to create workspace"}, nil
+	}
+
+	recordAudit(caller, "create_workspace", slug, name)
+	log.Printf("Workspace created: %s by %s", slug, caller)
+	return &pb.CreateWorkspaceResponse{Ok: true, Message: "workspace created", Workspace: &pb.WorkspaceInfo{
+		Id:          int64(ws.ID),
+		Slug:        ws.Slug,
+		Name:        ws.Name,
+		MyRole:      database.WorkspaceRoleOwner,
+		MemberCount: 1,
+	}}, nil
+}
+
+// ListWorkspaces - Các workspace của user
+func (s *chatServer) ListWorkspaces(ctx context.Context, _ *pb.Empty) (*pb.ListWorkspacesResponse, error) {
+	resp := &pb.ListWorkspacesResponse{}
+
+	memberships, err := db.GetUserWorkspaces(callerName(ctx))
+	if err != nil {
+		log.Printf("Error listing workspaces: %v", err)
+		return resp, nil
+	}
+	for _, m := range memberships {
+		count, err := db.CountWorkspaceMembers(m.WorkspaceID)
+		if err != nil {
+			log.Printf("Error counting members of workspace %s: %v", m.Workspace.Slug, err)
+		}
+		resp.Workspaces = append(resp.Workspaces, &pb.WorkspaceInfo{
+			Id:          int64(m.WorkspaceID),
+			Slug:        m.Workspace.Slug,
+			Name:        m.Workspace.Name,
+			MyRole:      m.Role,
+			MemberCount: int32(count),
+		})
+	}
+	return resp, nil
+}
+
+// AddWorkspaceMember - Server admin thêm user vào workspace
+func (s *chatServer) AddWorkspaceMember(ctx context.Context, req *pb.WorkspaceMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	if err := requireServerAdmin(ctx); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	ws, err := db.GetWorkspaceBySlug(req.Workspace)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("workspace %s not found", req.Workspace)}, nil
+		}
+		log.Printf("Error loading workspace %s: %v", req.Workspace, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "database error"}, nil
+	}
+	if _, err := db.GetUserByUsername(req.Username); err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: fmt.Sprintf("user %s not found", req.Username)}, nil
+	}
+
+	if err := db.AddWorkspaceMember(ws.ID, req.Username, database.WorkspaceRoleMember); err != nil {
+		log.Printf("Error adding %s to workspace %s: %v", req.Username, ws.Slug, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to add member"}, nil
+	}
+
+	recordAudit(caller, "add_workspace_member", req.Username, ws.Slug)
+	s.notify(req.Username, "notice", ws.Slug, fmt.Sprintf("%s added you to workspace %s", caller, ws.Name))
+	log.Printf("[WORKSPACE %s] %s added %s", ws.Slug, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: req.Username + " added to " + ws.Slug}, nil
+}
+
+// RemoveWorkspaceMember - Admin xóa user khỏi workspace (kèm các group trong workspace)
+func (s *chatServer) RemoveWorkspaceMember(ctx context.Context, req *pb.WorkspaceMemberRequest) (*pb.GroupActionResponse, error) {
+	caller := callerName(ctx)
+	ws, err := requireWorkspaceAdmin(req.Workspace, caller)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	role, err := db.GetWorkspaceRole(ws.ID, req.Username)
+	if err != nil {
+		return &pb.GroupActionResponse{Ok: false, Message: err.Error()}, nil
+	}
+	if role == database.WorkspaceRoleOwner {
+		return &pb.GroupActionResponse{Ok: false, Message: "cannot remove the workspace owner"}, nil
+	}
+
+	if err := db.RemoveWorkspaceMember(ws.ID, req.Username); err != nil {
+		log.Printf("Error removing %s from workspace %s: %v", req.Username, ws.Slug, err)
+		return &pb.GroupActionResponse{Ok: false, Message: "failed to remove member"}, nil
+	}
+
+	s.notify(req.Username, "notice", ws.Slug, fmt.Sprintf("you were removed from workspace %s", ws.Name))
+	log.Printf("[WORKSPACE %s] %s removed %s", ws.Slug, caller, req.Username)
+	return &pb.GroupActionResponse{Ok: true, Message: req.Username + " removed from " + ws.Slug}, nil
+}
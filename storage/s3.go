@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ObjectClient is the subset of the S3 API the S3 store needs. Clients of
+// S3-compatible services (AWS S3, MinIO, ...) can be wrapped to satisfy it;
+// LocalBucket is a stand-in for development.
+type ObjectClient interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) error
+	// GetObject returns ErrNotFound when the object does not exist
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// S3Store keeps blobs as objects of a bucket, under an optional key prefix
+type S3Store struct {
+	client ObjectClient
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns a store writing to bucket through client
+func NewS3Store(client ObjectClient, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: strings.Trim(prefix, "/")}
+}
+
+func (s *S3Store) objectKey(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return path.Join(s.prefix, key), nil
+}
+
+// Put uploads the blob as an object
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.PutObject(ctx, s.bucket, objectKey, r, size, contentType)
+}
+
+// Get downloads the object of the blob
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.GetObject(ctx, s.bucket, objectKey)
+}
+
+// Delete removes the object of the blob
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteObject(ctx, s.bucket, objectKey)
+}
+
+// LocalBucket is an ObjectClient keeping objects as files under
+// root/<bucket>/<key>, for running the S3 store without an S3 service
+type LocalBucket struct {
+	root string
+}
+
+// NewLocalBucket returns an object client storing under root
+func NewLocalBucket(root string) *LocalBucket {
+	return &LocalBucket{root: root}
+}
+
+func (b *LocalBucket) path(bucket, key string) (string, error) {
+	p := filepath.Join(b.root, bucket, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, filepath.Join(b.root, bucket)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return p, nil
+}
+
+// PutObject writes the object file
+func (b *LocalBucket) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) error {
+	p, err := b.path(bucket, key)
+	if err != nil {
+		return err
+	}
+	return writeFile(p, body, size)
+}
+
+// GetObject opens the object file
+func (b *LocalBucket) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	p, err := b.path(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return openFile(p)
+}
+
+// DeleteObject removes the object file
+func (b *LocalBucket) DeleteObject(ctx context.Context, bucket, key string) error {
+	p, err := b.path(bucket, key)
+	if err != nil {
+		return err
+	}
+	return removeFile(p)
+}